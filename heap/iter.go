@@ -0,0 +1,77 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package heap
+
+import "iter"
+
+// All returns an iterator over the elements in pop order, without modifying
+// the heap. Yielding the first k elements takes O(k*log(k)).
+//
+// The heap must not be modified during iteration.
+func (h *Heap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if h.Len() == 0 {
+			return
+		}
+
+		// frontier is a heap of indexes in h.data, the children of an
+		// index join the frontier once it is yielded.
+		frontier := []int{0}
+		less := func(a, b int) bool {
+			return h.Less(frontier[a], frontier[b])
+		}
+
+		for len(frontier) > 0 {
+			i := frontier[0]
+			if !yield(h.data[i]) {
+				return
+			}
+
+			n := len(frontier) - 1
+			frontier[0] = frontier[n]
+			frontier = frontier[:n]
+			downIndex(frontier, 0, less)
+
+			for _, j := range [2]int{2*i + 1, 2*i + 2} {
+				if j < h.Len() {
+					frontier = append(frontier, j)
+					upIndex(frontier, len(frontier)-1, less)
+				}
+			}
+		}
+	}
+}
+
+func upIndex(s []int, j int, less func(a, b int) bool) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !less(j, i) {
+			break
+		}
+		s[i], s[j] = s[j], s[i]
+		j = i
+	}
+}
+
+func downIndex(s []int, i int, less func(a, b int) bool) {
+	n := len(s)
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && less(j2, j1) {
+			j = j2 // = 2*i + 2  // right child
+		}
+		if !less(j, i) {
+			break
+		}
+		s[i], s[j] = s[j], s[i]
+		i = j
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package heap_test
+
+import (
+	"fmt"
+	"github.com/someonegg/gocontainer/heap"
+)
+
+func ExampleHeap_All() {
+	h := heap.New[element](8)
+
+	h.Push(3)
+	h.Push(4)
+	h.Push(1)
+	h.Push(6)
+	h.Push(0)
+	h.Push(2)
+	h.Push(1)
+
+	var s []element
+	for e := range h.All() {
+		s = append(s, e)
+	}
+	fmt.Println(s)
+
+	s = s[:0]
+	for e := range h.All() {
+		if e > 1 {
+			break
+		}
+		s = append(s, e)
+	}
+	fmt.Println(s)
+
+	fmt.Println(h.Len())
+
+	// Output:
+	// [0 1 1 2 3 4 6]
+	// [0 1 1]
+	// 7
+}
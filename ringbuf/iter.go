@@ -0,0 +1,32 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package ringbuf
+
+import "iter"
+
+// Chunks returns an iterator over the buffered data without consuming it,
+// as at most two contiguous chunks. The chunks alias the internal buffer, they
+// are valid until the next Write.
+func (b *FixedRingBuf) Chunks() iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		if b.size == 0 {
+			return
+		}
+
+		rbeg := b.beg
+		rend := rbeg + b.size
+		if rend <= b.N {
+			yield(b.buf[rbeg:rend])
+			return
+		}
+
+		if !yield(b.buf[rbeg:b.N]) {
+			return
+		}
+		yield(b.buf[0:(rend % b.N)])
+	}
+}
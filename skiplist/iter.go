@@ -0,0 +1,56 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package skiplist
+
+import "iter"
+
+// All returns an iterator over the elements in ascending order.
+// The current element can be removed during iteration.
+func (l *List) All() iter.Seq[*Element] {
+	return func(yield func(*Element) bool) {
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if !yield(e) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Backward returns an iterator over the elements in descending order.
+// The current element can be removed during iteration.
+func (l *List) Backward() iter.Seq[*Element] {
+	return func(yield func(*Element) bool) {
+		for e := l.Back(); e != nil; {
+			prev := e.Prev()
+			if !yield(e) {
+				return
+			}
+			e = prev
+		}
+	}
+}
+
+// From returns an iterator over the elements not less than score in
+// ascending order, it yields nothing when score is nil.
+// The current element can be removed during iteration.
+func (l *List) From(score Scorable) iter.Seq[*Element] {
+	return func(yield func(*Element) bool) {
+		if score == nil {
+			return
+		}
+		p, _ := l.searchToLower(score, nil)
+		for e := p.next(); e != l.root; {
+			next := e.next()
+			if !yield(e) {
+				return
+			}
+			e = next
+		}
+	}
+}
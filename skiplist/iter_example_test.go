@@ -0,0 +1,62 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package skiplist_test
+
+import (
+	"fmt"
+	"github.com/someonegg/gocontainer/skiplist"
+)
+
+func ExampleList_All() {
+	l := skiplist.NewList(itemCompare)
+
+	l.Add(&item{score: 3})
+	l.Add(&item{score: 1})
+	l.Add(&item{score: 2})
+	l.Add(&item{score: 2})
+
+	var scores []int
+	for e := range l.All() {
+		scores = append(scores, e.Value.(*item).score)
+		if e.Value.(*item).score == 2 {
+			l.Remove(e)
+		}
+	}
+	fmt.Println(scores)
+
+	scores = scores[:0]
+	for e := range l.Backward() {
+		scores = append(scores, e.Value.(*item).score)
+	}
+	fmt.Println(scores)
+
+	// Output:
+	// [1 2 2 3]
+	// [3 1]
+}
+
+func ExampleList_From() {
+	l := skiplist.NewList(itemCompare)
+
+	for _, score := range []int{5, 1, 3, 3, 7, 3} {
+		l.Add(&item{score: score})
+	}
+
+	for _, from := range []int{3, 4, 0, 8} {
+		var scores []int
+		for e := range l.From(from) {
+			scores = append(scores, e.Value.(*item).score)
+		}
+		fmt.Println(from, scores)
+	}
+
+	// Output:
+	// 3 [3 3 3 5 7]
+	// 4 [5 7]
+	// 0 [1 3 3 3 5 7]
+	// 8 []
+}
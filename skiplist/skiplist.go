@@ -17,7 +17,7 @@ package skiplist
 
 import (
 	"fmt"
-	"time"
+	"math/rand"
 )
 
 const (
@@ -90,6 +90,7 @@ type List struct {
 	len  int
 	root *Element
 	rnd  splitMix64
+	src  rand.Source64
 }
 
 // NewList creates a new skip list, with DefaultLevel\compare.
@@ -99,6 +100,13 @@ func NewList(compare CompareFunc) *List {
 
 // NewListEx creates a new skip list, with maxLevel\compare.
 func NewListEx(maxLevel int, compare CompareFunc) *List {
+	return NewListSource(maxLevel, compare, nil)
+}
+
+// NewListSource creates a new skip list, with maxLevel\compare, generating
+// levels from src. A seeded src (see NewSource) makes the shape of the list
+// reproducible, a nil src means a generator with a random seed.
+func NewListSource(maxLevel int, compare CompareFunc, src rand.Source64) *List {
 	if maxLevel < 1 || maxLevel > MaximumLevel {
 		panic("maxLevel < 1 or maxLevel > MaximumLevel")
 	}
@@ -113,7 +121,8 @@ func NewListEx(maxLevel int, compare CompareFunc) *List {
 			lev:  make([]level, maxLevel),
 			list: nil,
 		},
-		rnd: splitMix64(time.Now().Unix()),
+		rnd: splitMix64(newSeed()),
+		src: src,
 	}
 
 	for i := 0; i < l.maxL; i++ {
@@ -256,11 +265,18 @@ func (l *List) add(e *Element) {
 	l.len++
 }
 
+func (l *List) int63() int64 {
+	if l.src != nil {
+		return l.src.Int63()
+	}
+	return l.rnd.Int63()
+}
+
 func (l *List) randLevel() int {
 	const RANDMAX int64 = 65536
 	const RANDTHRESHOLD int64 = int64(float32(RANDMAX) * PROPABILITY)
 	nlev := 1
-	for l.rnd.Int63()%RANDMAX < RANDTHRESHOLD && nlev < l.maxL {
+	for l.int63()%RANDMAX < RANDTHRESHOLD && nlev < l.maxL {
 		nlev++
 	}
 	return nlev
@@ -390,6 +406,19 @@ func (l *List) searchToScore(score Scorable, path *searchPath) (*Element, bool)
 	return l.searchToPos(poscomp, path)
 }
 
+// searchToLower stops before the first element not less than score, its
+// next element is the lower bound.
+func (l *List) searchToLower(score Scorable, path *searchPath) (*Element, bool) {
+	poscomp := func(ilev int, p, n *Element) int {
+		if l.comp(score, n.Value) > 0 {
+			return 1
+		}
+		return -1
+	}
+
+	return l.searchToPos(poscomp, path)
+}
+
 func (l *List) searchToRank(rank int, path *searchPath) (*Element, bool) {
 	span := rank + 1
 	poscomp := func(ilev int, p, n *Element) int {
@@ -0,0 +1,196 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import (
+	"encoding/binary"
+
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+// The binary format is a version byte, the number of entries as a uvarint,
+// then the key and the value of each entry in ascending key order.
+
+const binaryVersion = 1
+
+// encodeBinary appends the binary format of n entries from iterate to b.
+func encodeBinary[K, V any](b []byte, n int, iterate func(uskiplist.Iterator[entry[K, V]]), kc Codec[K], vc Codec[V]) ([]byte, error) {
+	b = append(b, binaryVersion)
+	b = binary.AppendUvarint(b, uint64(n))
+
+	var err error
+	iterate(func(e *entry[K, V]) bool {
+		if b, err = kc.Append(b, e.key); err != nil {
+			return false
+		}
+		b, err = vc.Append(b, e.value)
+		return err == nil
+	})
+	return b, err
+}
+
+// decodeBinary decodes the entries from data and appends them with add, which
+// returns false for out of order keys.
+func decodeBinary[K, V any](data []byte, kc Codec[K], vc Codec[V], add func(k K, v V) bool) error {
+	if len(data) < 1 || data[0] != binaryVersion {
+		return ErrCorrupt
+	}
+	data = data[1:]
+
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return ErrCorrupt
+	}
+	data = data[n:]
+
+	for ; count > 0; count-- {
+		k, n, err := kc.Decode(data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+
+		v, n, err := vc.Decode(data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+
+		if !add(k, v) {
+			return ErrCorrupt
+		}
+	}
+	if len(data) != 0 {
+		return ErrCorrupt
+	}
+	return nil
+}
+
+// defaultCodecs returns the default codecs of K and V.
+func defaultCodecs[K, V any]() (Codec[K], Codec[V], error) {
+	kc, err := DefaultCodec[K]()
+	if err != nil {
+		return nil, nil, err
+	}
+	vc, err := DefaultCodec[V]()
+	if err != nil {
+		return nil, nil, err
+	}
+	return kc, vc, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the default codecs,
+// see DefaultCodec and EncodeBinary. It has a value receiver, so a map held by
+// value is encoded too.
+func (m Map[K, V]) MarshalBinary() ([]byte, error) {
+	kc, vc, err := defaultCodecs[K, V]()
+	if err != nil {
+		return nil, err
+	}
+	return m.EncodeBinary(nil, kc, vc)
+}
+
+// EncodeBinary appends the entries of m in ascending key order to b, encoding
+// keys with kc and values with vc.
+func (m *Map[K, V]) EncodeBinary(b []byte, kc Codec[K], vc Codec[V]) ([]byte, error) {
+	return encodeBinary(b, m.Len(), entries(m.Len(), m.list.Iterate), kc, vc)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler with the default
+// codecs, see DefaultCodec and DecodeBinary.
+func (m *Map[K, V]) UnmarshalBinary(data []byte) error {
+	kc, vc, err := defaultCodecs[K, V]()
+	if err != nil {
+		return err
+	}
+	return m.DecodeBinary(data, kc, vc)
+}
+
+// DecodeBinary reinitializes the map, a ranked map stays ranked, with the
+// entries encoded by EncodeBinary, decoding keys with kc and values with vc.
+// As the entries are sorted, it takes O(n) time. On error, the map holds the
+// entries decoded before it.
+func (m *Map[K, V]) DecodeBinary(data []byte, kc Codec[K], vc Codec[V]) error {
+	m.Init()
+	b := NewBuilder(m, BuildOptions{})
+	return decodeBinary(data, kc, vc, b.Append)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the default codecs,
+// see DefaultCodec and EncodeBinary. It has a value receiver, so a map held by
+// value is encoded too.
+func (m OrderedMap[K, V]) MarshalBinary() ([]byte, error) {
+	kc, vc, err := defaultCodecs[K, V]()
+	if err != nil {
+		return nil, err
+	}
+	return m.EncodeBinary(nil, kc, vc)
+}
+
+// EncodeBinary appends the entries of m in ascending key order to b, encoding
+// keys with kc and values with vc.
+func (m *OrderedMap[K, V]) EncodeBinary(b []byte, kc Codec[K], vc Codec[V]) ([]byte, error) {
+	return encodeBinary(b, m.Len(), entries(m.Len(), m.list.Iterate), kc, vc)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler with the default
+// codecs, see DefaultCodec and DecodeBinary.
+func (m *OrderedMap[K, V]) UnmarshalBinary(data []byte) error {
+	kc, vc, err := defaultCodecs[K, V]()
+	if err != nil {
+		return err
+	}
+	return m.DecodeBinary(data, kc, vc)
+}
+
+// DecodeBinary reinitializes the map, a ranked map stays ranked, with the
+// entries encoded by EncodeBinary, decoding keys with kc and values with vc.
+// As the entries are sorted, it takes O(n) time. On error, the map holds the
+// entries decoded before it.
+func (m *OrderedMap[K, V]) DecodeBinary(data []byte, kc Codec[K], vc Codec[V]) error {
+	m.Init()
+	b := NewOrderedBuilder(m, BuildOptions{})
+	return decodeBinary(data, kc, vc, b.Append)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the default codecs,
+// see DefaultCodec and EncodeBinary. It has a value receiver, so a map held by
+// value is encoded too.
+func (m FuncMap[K, V]) MarshalBinary() ([]byte, error) {
+	kc, vc, err := defaultCodecs[K, V]()
+	if err != nil {
+		return nil, err
+	}
+	return m.EncodeBinary(nil, kc, vc)
+}
+
+// EncodeBinary appends the entries of m in ascending key order to b, encoding
+// keys with kc and values with vc.
+func (m *FuncMap[K, V]) EncodeBinary(b []byte, kc Codec[K], vc Codec[V]) ([]byte, error) {
+	return encodeBinary(b, m.Len(), entries(m.Len(), m.list.Iterate), kc, vc)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler with the default
+// codecs, see DefaultCodec and DecodeBinary.
+func (m *FuncMap[K, V]) UnmarshalBinary(data []byte) error {
+	kc, vc, err := defaultCodecs[K, V]()
+	if err != nil {
+		return err
+	}
+	return m.DecodeBinary(data, kc, vc)
+}
+
+// DecodeBinary reinitializes the map, a ranked map stays ranked, with the
+// entries encoded by EncodeBinary, decoding keys with kc and values with vc.
+// As the entries are sorted, it takes O(n) time. On error, the map holds the
+// entries decoded before it.
+func (m *FuncMap[K, V]) DecodeBinary(data []byte, kc Codec[K], vc Codec[V]) error {
+	if m.list.Less() == nil {
+		return ErrNoLess
+	}
+	m.Init()
+	b := NewFuncBuilder(m, BuildOptions{})
+	return decodeBinary(data, kc, vc, b.Append)
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap_test
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/someonegg/gocontainer/sortedmap"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*sortedmap.OrderedMap[int, int])(nil)
+	_ encoding.BinaryUnmarshaler = (*sortedmap.OrderedMap[int, int])(nil)
+)
+
+type score int16
+
+func TestOrderedMapBinary(t *testing.T) {
+	m := sortedmap.NewOrdered[string, score]()
+	for i, k := range []string{"", "b", "a", "long key \x00 with zero", "z"} {
+		m.Set(k, score(-1000*i))
+	}
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m2 := sortedmap.NewOrderedRanked[string, score]()
+	m2.Set("stale", 1)
+	if err := m2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if m2.Len() != m.Len() || m2.Get("stale") != nil {
+		t.Fatalf("UnmarshalBinary Len = %d, want %d", m2.Len(), m.Len())
+	}
+	m.Range(func(k string, v *score) bool {
+		if got := m2.Get(k); got == nil || *got != *v {
+			t.Fatalf("UnmarshalBinary %q = %v, want %d", k, got, *v)
+		}
+		return true
+	})
+	if k, _, _ := m2.At(1); k != "a" {
+		t.Fatalf("At(1) = %q, want a", k)
+	}
+
+	for _, n := range []int{0, 1, len(data) / 2, len(data) - 1} {
+		if err := m2.UnmarshalBinary(data[:n]); !errors.Is(err, sortedmap.ErrCorrupt) {
+			t.Fatalf("UnmarshalBinary truncated to %d = %v, want ErrCorrupt", n, err)
+		}
+	}
+	if err := m2.UnmarshalBinary(append(data, 0)); !errors.Is(err, sortedmap.ErrCorrupt) {
+		t.Fatalf("UnmarshalBinary with trailing data = %v, want ErrCorrupt", err)
+	}
+
+	// Out of order keys are rejected.
+	bad := sortedmap.NewFunc[string, score](func(a, b string) bool { return a > b })
+	if err := bad.UnmarshalBinary(data); !errors.Is(err, sortedmap.ErrCorrupt) {
+		t.Fatalf("UnmarshalBinary out of order = %v, want ErrCorrupt", err)
+	}
+}
+
+func TestMapBinaryCodecs(t *testing.T) {
+	m := sortedmap.New[testKey, time.Time]()
+	if _, err := m.MarshalBinary(); err == nil {
+		t.Fatal("MarshalBinary without a key codec succeeded")
+	}
+
+	now := time.Unix(1700000000, 5).UTC()
+	m.Set(testKey{2, 1}, now)
+	m.Set(testKey{1, 3}, now.Add(time.Hour))
+
+	kc := testKeyCodec{}
+	vc, err := sortedmap.DefaultCodec[time.Time]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.EncodeBinary([]byte("prefix"), kc, vc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m2 := sortedmap.New[testKey, time.Time]()
+	if err := m2.DecodeBinary(data[len("prefix"):], kc, vc); err != nil {
+		t.Fatal(err)
+	}
+	var keys []testKey
+	m2.Range(func(k testKey, v *time.Time) bool {
+		keys = append(keys, k)
+		if !v.Equal(*m.Get(k)) {
+			t.Fatalf("DecodeBinary %v = %v", k, v)
+		}
+		return true
+	})
+	if !reflect.DeepEqual(keys, []testKey{{1, 3}, {2, 1}}) {
+		t.Fatalf("DecodeBinary keys = %v", keys)
+	}
+}
+
+type testKeyCodec struct{}
+
+func (testKeyCodec) Append(b []byte, k testKey) ([]byte, error) {
+	b = binary.AppendVarint(b, int64(k.major))
+	return binary.AppendVarint(b, int64(k.minor)), nil
+}
+
+func (testKeyCodec) Decode(b []byte) (k testKey, n int, err error) {
+	major, n1 := binary.Varint(b)
+	if n1 <= 0 {
+		return k, 0, sortedmap.ErrCorrupt
+	}
+	minor, n2 := binary.Varint(b[n1:])
+	if n2 <= 0 {
+		return k, 0, sortedmap.ErrCorrupt
+	}
+	return testKey{int(major), int(minor)}, n1 + n2, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import "github.com/someonegg/gocontainer/uskiplist"
+
+// Bounds tells which ends of a key range [lo, hi] are included.
+type Bounds = uskiplist.Bounds
+
+const (
+	// IncludeLo includes lo in the range.
+	IncludeLo = uskiplist.IncludeLo
+	// IncludeHi includes hi in the range.
+	IncludeHi = uskiplist.IncludeHi
+
+	// Open excludes both ends: (lo, hi).
+	Open = uskiplist.Open
+	// HalfOpen includes lo only: [lo, hi).
+	HalfOpen = uskiplist.HalfOpen
+	// Closed includes both ends: [lo, hi].
+	Closed = uskiplist.Closed
+)
+
+// RangeBetween calls fn once for each entry with key between lo and hi, in
+// ascending key order. bounds tells whether lo and hi are included.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *Map[K, V]) RangeBetween(lo, hi K, bounds Bounds, fn func(K, *V) bool) {
+	m.list.IterateBetween(lo, hi, bounds, func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// DeleteRange removes all entries with key between lo and hi in one pass, and
+// returns the number of removed entries. bounds tells whether lo and hi are
+// included.
+func (m *Map[K, V]) DeleteRange(lo, hi K, bounds Bounds) int {
+	if m.snaps != nil {
+		m.list.IterateBetween(lo, hi, bounds, func(e *entry[K, V]) bool {
+			m.record(e.key, e)
+			return true
+		})
+	}
+	return m.list.DeleteRange(lo, hi, bounds)
+}
+
+// RangeBetween calls fn once for each entry with key between lo and hi, in
+// ascending key order. bounds tells whether lo and hi are included.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *OrderedMap[K, V]) RangeBetween(lo, hi K, bounds Bounds, fn func(K, *V) bool) {
+	m.list.IterateBetween(lo, hi, bounds, func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// DeleteRange removes all entries with key between lo and hi in one pass, and
+// returns the number of removed entries. bounds tells whether lo and hi are
+// included.
+func (m *OrderedMap[K, V]) DeleteRange(lo, hi K, bounds Bounds) int {
+	if m.snaps != nil {
+		m.list.IterateBetween(lo, hi, bounds, func(e *entry[K, V]) bool {
+			m.record(e.key, e)
+			return true
+		})
+	}
+	return m.list.DeleteRange(lo, hi, bounds)
+}
+
+// RangeBetween calls fn once for each entry with key between lo and hi, in
+// ascending key order. bounds tells whether lo and hi are included.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *FuncMap[K, V]) RangeBetween(lo, hi K, bounds Bounds, fn func(K, *V) bool) {
+	m.list.IterateBetween(lo, hi, bounds, func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// DeleteRange removes all entries with key between lo and hi in one pass, and
+// returns the number of removed entries. bounds tells whether lo and hi are
+// included.
+func (m *FuncMap[K, V]) DeleteRange(lo, hi K, bounds Bounds) int {
+	if m.snaps != nil {
+		m.list.IterateBetween(lo, hi, bounds, func(e *entry[K, V]) bool {
+			m.record(e.key, e)
+			return true
+		})
+	}
+	return m.list.DeleteRange(lo, hi, bounds)
+}
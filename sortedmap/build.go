@@ -0,0 +1,165 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import (
+	"github.com/someonegg/gocontainer/cmp"
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+// BuildOptions configures a Builder, see uskiplist.BuildOptions.
+type BuildOptions = uskiplist.BuildOptions
+
+// Builder appends entries in ascending key order to the end of a Map in O(1)
+// amortized time, without searching.
+//
+// The map must not be modified by other means while the Builder is in use.
+type Builder[K cmp.Key[K], V any] struct {
+	m *Map[K, V]
+	b *uskiplist.Builder[K, entry[K, V], *entry[K, V]]
+}
+
+// NewBuilder creates a Builder which appends to m.
+func NewBuilder[K cmp.Key[K], V any](m *Map[K, V], opts BuildOptions) *Builder[K, V] {
+	return &Builder[K, V]{
+		m: m,
+		b: uskiplist.NewBuilder(&m.list, opts),
+	}
+}
+
+// Append appends k and v after the last entry. It returns false, or panics
+// when Strict, if k is not greater than the last key.
+func (b *Builder[K, V]) Append(k K, v V) bool {
+	if !b.b.Append(&entry[K, V]{key: k, value: v}) {
+		return false
+	}
+	if b.m.snaps != nil {
+		b.m.record(k, nil)
+	}
+	return true
+}
+
+// BuildFromSorted reinitializes the map, a ranked map stays ranked, with keys
+// in ascending order and their values in O(n) time. keys and values must have
+// the same length. It returns the number of entries added, out of order keys
+// are skipped or panic, see Builder.
+func (m *Map[K, V]) BuildFromSorted(keys []K, values []V, opts BuildOptions) int {
+	if len(keys) != len(values) {
+		panic("len(keys) != len(values)")
+	}
+
+	m.Init()
+	b := NewBuilder(m, opts)
+
+	n := 0
+	for i, k := range keys {
+		if b.Append(k, values[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+// OrderedBuilder appends entries in ascending key order to the end of an
+// OrderedMap in O(1) amortized time, without searching.
+//
+// The map must not be modified by other means while the OrderedBuilder is in
+// use.
+type OrderedBuilder[K cmp.Ordered, V any] struct {
+	m *OrderedMap[K, V]
+	b *uskiplist.BuilderO[K, entry[K, V], *entry[K, V]]
+}
+
+// NewOrderedBuilder creates a OrderedBuilder which appends to m.
+func NewOrderedBuilder[K cmp.Ordered, V any](m *OrderedMap[K, V], opts BuildOptions) *OrderedBuilder[K, V] {
+	return &OrderedBuilder[K, V]{
+		m: m,
+		b: uskiplist.NewBuilderO(&m.list, opts),
+	}
+}
+
+// Append appends k and v after the last entry. It returns false, or panics
+// when Strict, if k is not greater than the last key.
+func (b *OrderedBuilder[K, V]) Append(k K, v V) bool {
+	if !b.b.Append(&entry[K, V]{key: k, value: v}) {
+		return false
+	}
+	if b.m.snaps != nil {
+		b.m.record(k, nil)
+	}
+	return true
+}
+
+// BuildFromSorted reinitializes the map, a ranked map stays ranked, with keys
+// in ascending order and their values in O(n) time. keys and values must have
+// the same length. It returns the number of entries added, out of order keys
+// are skipped or panic, see OrderedBuilder.
+func (m *OrderedMap[K, V]) BuildFromSorted(keys []K, values []V, opts BuildOptions) int {
+	if len(keys) != len(values) {
+		panic("len(keys) != len(values)")
+	}
+
+	m.Init()
+	b := NewOrderedBuilder(m, opts)
+
+	n := 0
+	for i, k := range keys {
+		if b.Append(k, values[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+// FuncBuilder appends entries in ascending key order to the end of a
+// FuncMap in O(1) amortized time, without searching.
+//
+// The map must not be modified by other means while the FuncBuilder is in
+// use.
+type FuncBuilder[K, V any] struct {
+	m *FuncMap[K, V]
+	b *uskiplist.BuilderF[K, entry[K, V], *entry[K, V]]
+}
+
+// NewFuncBuilder creates a FuncBuilder which appends to m.
+func NewFuncBuilder[K, V any](m *FuncMap[K, V], opts BuildOptions) *FuncBuilder[K, V] {
+	return &FuncBuilder[K, V]{
+		m: m,
+		b: uskiplist.NewBuilderF(&m.list, opts),
+	}
+}
+
+// Append appends k and v after the last entry. It returns false, or panics
+// when Strict, if k is not greater than the last key.
+func (b *FuncBuilder[K, V]) Append(k K, v V) bool {
+	if !b.b.Append(&entry[K, V]{key: k, value: v}) {
+		return false
+	}
+	if b.m.snaps != nil {
+		b.m.record(k, nil)
+	}
+	return true
+}
+
+// BuildFromSorted reinitializes the map, a ranked map stays ranked, with keys
+// in ascending order and their values in O(n) time. keys and values must have
+// the same length. It returns the number of entries added, out of order keys
+// are skipped or panic, see FuncBuilder.
+func (m *FuncMap[K, V]) BuildFromSorted(keys []K, values []V, opts BuildOptions) int {
+	if len(keys) != len(values) {
+		panic("len(keys) != len(values)")
+	}
+
+	m.Init()
+	b := NewFuncBuilder(m, opts)
+
+	n := 0
+	for i, k := range keys {
+		if b.Append(k, values[i]) {
+			n++
+		}
+	}
+	return n
+}
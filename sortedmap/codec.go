@@ -0,0 +1,270 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+var (
+	// ErrCorrupt is returned when decoding malformed binary data.
+	ErrCorrupt = errors.New("corrupt binary data")
+
+	// ErrNoLess is returned when decoding into a FuncMap which has no less
+	// function, like the zero value.
+	ErrNoLess = errors.New("FuncMap without less function")
+)
+
+// Codec encodes and decodes the keys or values of a sorted map in the binary
+// format, see EncodeBinary.
+type Codec[T any] interface {
+	// Append appends the encoding of v to b.
+	Append(b []byte, v T) ([]byte, error)
+	// Decode decodes v from the beginning of b, n is the number of bytes
+	// consumed.
+	Decode(b []byte) (v T, n int, err error)
+}
+
+// DefaultCodec returns the codec used by MarshalBinary and UnmarshalBinary.
+//
+// It supports types implementing encoding.BinaryMarshaler whose pointer
+// implements encoding.BinaryUnmarshaler, and types whose underlying type is a
+// boolean, an integer, a float, a string or a byte slice. Integers use varints
+// and floats their IEEE 754 bits, so the encoding does not depend on the
+// platform.
+func DefaultCodec[T any]() (Codec[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	if t.Implements(binaryMarshalerType) && reflect.PtrTo(t).Implements(binaryUnmarshalerType) {
+		return marshalerCodec[T]{}, nil
+	}
+
+	c := kindCodec[T]{size: t.Size()}
+	switch t.Kind() {
+	case reflect.Bool:
+		c.append, c.decode = appendBool, decodeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c.append, c.decode = appendInt, decodeInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		c.append, c.decode = appendUint, decodeUint
+	case reflect.Float32, reflect.Float64:
+		c.append, c.decode = appendFloat, decodeFloat
+	case reflect.String:
+		c.append, c.decode = appendString, decodeString
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return nil, fmt.Errorf("no default codec for %v", t)
+		}
+		c.append, c.decode = appendBytes, decodeBytes
+	default:
+		return nil, fmt.Errorf("no default codec for %v", t)
+	}
+	return c, nil
+}
+
+var (
+	binaryMarshalerType   = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+)
+
+// marshalerCodec stores the length prefixed result of MarshalBinary.
+type marshalerCodec[T any] struct{}
+
+func (marshalerCodec[T]) Append(b []byte, v T) ([]byte, error) {
+	data, err := any(v).(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return b, err
+	}
+	return appendChunk(b, data), nil
+}
+
+func (marshalerCodec[T]) Decode(b []byte) (v T, n int, err error) {
+	data, n, err := decodeChunk(b)
+	if err != nil {
+		return v, 0, err
+	}
+	err = any(&v).(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+	return v, n, err
+}
+
+// kindCodec accesses T through a pointer to its underlying type.
+type kindCodec[T any] struct {
+	size   uintptr
+	append func(b []byte, p unsafe.Pointer, size uintptr) []byte
+	decode func(b []byte, p unsafe.Pointer, size uintptr) (int, error)
+}
+
+func (c kindCodec[T]) Append(b []byte, v T) ([]byte, error) {
+	return c.append(b, unsafe.Pointer(&v), c.size), nil
+}
+
+func (c kindCodec[T]) Decode(b []byte) (v T, n int, err error) {
+	n, err = c.decode(b, unsafe.Pointer(&v), c.size)
+	return v, n, err
+}
+
+func appendBool(b []byte, p unsafe.Pointer, size uintptr) []byte {
+	if *(*bool)(p) {
+		return append(b, 1)
+	}
+	return append(b, 0)
+}
+
+func decodeBool(b []byte, p unsafe.Pointer, size uintptr) (int, error) {
+	if len(b) < 1 || b[0] > 1 {
+		return 0, ErrCorrupt
+	}
+	*(*bool)(p) = b[0] == 1
+	return 1, nil
+}
+
+func loadInt(p unsafe.Pointer, size uintptr) int64 {
+	switch size {
+	case 1:
+		return int64(*(*int8)(p))
+	case 2:
+		return int64(*(*int16)(p))
+	case 4:
+		return int64(*(*int32)(p))
+	}
+	return *(*int64)(p)
+}
+
+func storeInt(p unsafe.Pointer, size uintptr, x int64) {
+	switch size {
+	case 1:
+		*(*int8)(p) = int8(x)
+	case 2:
+		*(*int16)(p) = int16(x)
+	case 4:
+		*(*int32)(p) = int32(x)
+	default:
+		*(*int64)(p) = x
+	}
+}
+
+func appendInt(b []byte, p unsafe.Pointer, size uintptr) []byte {
+	return binary.AppendVarint(b, loadInt(p, size))
+}
+
+func decodeInt(b []byte, p unsafe.Pointer, size uintptr) (int, error) {
+	x, n := binary.Varint(b)
+	if n <= 0 {
+		return 0, ErrCorrupt
+	}
+	storeInt(p, size, x)
+	if loadInt(p, size) != x {
+		return 0, ErrCorrupt
+	}
+	return n, nil
+}
+
+func loadUint(p unsafe.Pointer, size uintptr) uint64 {
+	switch size {
+	case 1:
+		return uint64(*(*uint8)(p))
+	case 2:
+		return uint64(*(*uint16)(p))
+	case 4:
+		return uint64(*(*uint32)(p))
+	}
+	return *(*uint64)(p)
+}
+
+func storeUint(p unsafe.Pointer, size uintptr, x uint64) {
+	switch size {
+	case 1:
+		*(*uint8)(p) = uint8(x)
+	case 2:
+		*(*uint16)(p) = uint16(x)
+	case 4:
+		*(*uint32)(p) = uint32(x)
+	default:
+		*(*uint64)(p) = x
+	}
+}
+
+func appendUint(b []byte, p unsafe.Pointer, size uintptr) []byte {
+	return binary.AppendUvarint(b, loadUint(p, size))
+}
+
+func decodeUint(b []byte, p unsafe.Pointer, size uintptr) (int, error) {
+	x, n := binary.Uvarint(b)
+	if n <= 0 {
+		return 0, ErrCorrupt
+	}
+	storeUint(p, size, x)
+	if loadUint(p, size) != x {
+		return 0, ErrCorrupt
+	}
+	return n, nil
+}
+
+func appendFloat(b []byte, p unsafe.Pointer, size uintptr) []byte {
+	if size == 4 {
+		return binary.LittleEndian.AppendUint32(b, math.Float32bits(*(*float32)(p)))
+	}
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(*(*float64)(p)))
+}
+
+func decodeFloat(b []byte, p unsafe.Pointer, size uintptr) (int, error) {
+	if len(b) < int(size) {
+		return 0, ErrCorrupt
+	}
+	if size == 4 {
+		*(*float32)(p) = math.Float32frombits(binary.LittleEndian.Uint32(b))
+	} else {
+		*(*float64)(p) = math.Float64frombits(binary.LittleEndian.Uint64(b))
+	}
+	return int(size), nil
+}
+
+func appendChunk(b []byte, data []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+// decodeChunk returns the data of a chunk, which refers to b.
+func decodeChunk(b []byte) (data []byte, n int, err error) {
+	l, n := binary.Uvarint(b)
+	if n <= 0 || l > uint64(len(b)-n) {
+		return nil, 0, ErrCorrupt
+	}
+	return b[n : n+int(l)], n + int(l), nil
+}
+
+func appendString(b []byte, p unsafe.Pointer, size uintptr) []byte {
+	s := *(*string)(p)
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+func decodeString(b []byte, p unsafe.Pointer, size uintptr) (int, error) {
+	data, n, err := decodeChunk(b)
+	if err != nil {
+		return 0, err
+	}
+	*(*string)(p) = string(data)
+	return n, nil
+}
+
+func appendBytes(b []byte, p unsafe.Pointer, size uintptr) []byte {
+	return appendChunk(b, *(*[]byte)(p))
+}
+
+func decodeBytes(b []byte, p unsafe.Pointer, size uintptr) (int, error) {
+	data, n, err := decodeChunk(b)
+	if err != nil {
+		return 0, err
+	}
+	*(*[]byte)(p) = append([]byte(nil), data...)
+	return n, nil
+}
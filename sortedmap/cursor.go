@@ -0,0 +1,66 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import "github.com/someonegg/gocontainer/uskiplist"
+
+// Cursor is a positional iterator over a sorted map in ascending key order,
+// it can be paused at any time and resumed later, or moved in lockstep with
+// another one.
+//
+// The map may be modified while a cursor is open. After a modification, Next
+// moves to the first entry whose key is greater than the current one, so the
+// current entry may be deleted: Key and Value keep returning it until Next.
+type Cursor[K, V any] struct {
+	c *uskiplist.Cursor[K, entry[K, V], *entry[K, V]]
+}
+
+// SeekFirst moves the cursor to the first entry.
+func (c *Cursor[K, V]) SeekFirst() {
+	c.c.SeekFirst()
+}
+
+// Seek moves the cursor to the first entry with key greater than or equal to
+// k.
+func (c *Cursor[K, V]) Seek(k K) {
+	c.c.Seek(k)
+}
+
+// Valid tells whether the cursor is at an entry.
+func (c *Cursor[K, V]) Valid() bool {
+	return c.c.Valid()
+}
+
+// Next moves the cursor to the next entry, the cursor becomes invalid after
+// the last entry.
+func (c *Cursor[K, V]) Next() {
+	c.c.Next()
+}
+
+// Key returns the key of the current entry, the cursor must be valid.
+func (c *Cursor[K, V]) Key() K {
+	return c.c.Elem().key
+}
+
+// Value returns a pointer to the value of the current entry, the cursor must
+// be valid.
+func (c *Cursor[K, V]) Value() *V {
+	return &c.c.Elem().value
+}
+
+// Cursor returns a new cursor at the first entry, see Cursor.
+func (m *Map[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{m.list.Cursor()}
+}
+
+// Cursor returns a new cursor at the first entry, see Cursor.
+func (m *OrderedMap[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{m.list.Cursor()}
+}
+
+// Cursor returns a new cursor at the first entry, see Cursor.
+func (m *FuncMap[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{m.list.Cursor()}
+}
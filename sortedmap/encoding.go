@@ -0,0 +1,354 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+// Gob encodes the keys and the values of a map as two slices.
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// textKeys tells whether K is encoded as JSON object names.
+func textKeys[K any]() bool {
+	t := reflect.TypeOf((*K)(nil)).Elem()
+	if t.Implements(textMarshalerType) && reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	return t.Kind() == reflect.String
+}
+
+func keyText[K any](k K) (string, error) {
+	if tm, ok := any(k).(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	return reflect.ValueOf(k).String(), nil
+}
+
+func textKey[K any](text string) (k K, err error) {
+	if tu, ok := any(&k).(encoding.TextUnmarshaler); ok {
+		err = tu.UnmarshalText([]byte(text))
+		return k, err
+	}
+	reflect.ValueOf(&k).Elem().SetString(text)
+	return k, nil
+}
+
+// entries returns iterate, or an empty iteration when the map has no entries.
+// The skiplist of a map which was never initialized has no root to iterate,
+// its zero value still encodes as an empty map.
+func entries[K, V any](n int, iterate func(uskiplist.Iterator[entry[K, V]])) func(uskiplist.Iterator[entry[K, V]]) {
+	if n == 0 {
+		return func(uskiplist.Iterator[entry[K, V]]) {}
+	}
+	return iterate
+}
+
+func encodeJSON[K, V any](iterate func(uskiplist.Iterator[entry[K, V]])) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+
+	text := textKeys[K]()
+	if text {
+		buf.WriteByte('{')
+	} else {
+		buf.WriteByte('[')
+	}
+
+	first := true
+	iterate(func(e *entry[K, V]) bool {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		var kb, vb []byte
+		if text {
+			var s string
+			if s, err = keyText(e.key); err != nil {
+				return false
+			}
+			kb, err = json.Marshal(s)
+		} else {
+			kb, err = json.Marshal(e.key)
+		}
+		if err != nil {
+			return false
+		}
+		if vb, err = json.Marshal(e.value); err != nil {
+			return false
+		}
+
+		if text {
+			buf.Write(kb)
+			buf.WriteByte(':')
+			buf.Write(vb)
+		} else {
+			buf.WriteByte('[')
+			buf.Write(kb)
+			buf.WriteByte(',')
+			buf.Write(vb)
+			buf.WriteByte(']')
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if text {
+		buf.WriteByte('}')
+	} else {
+		buf.WriteByte(']')
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeJSON decodes the entries encoded by encodeJSON, and adds them with
+// add in the order of data.
+func decodeJSON[K, V any](data []byte, add func(k K, v V)) error {
+	text := textKeys[K]()
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	begin, end := json.Delim('['), json.Delim(']')
+	if text {
+		begin, end = '{', '}'
+	}
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != begin {
+		return fmt.Errorf("unexpected JSON token %v, want %v", tok, begin)
+	}
+
+	for dec.More() {
+		var k K
+		var v V
+		if text {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if k, err = textKey[K](tok.(string)); err != nil {
+				return err
+			}
+			if err := dec.Decode(&v); err != nil {
+				return err
+			}
+		} else {
+			var pair []json.RawMessage
+			if err := dec.Decode(&pair); err != nil {
+				return err
+			}
+			if len(pair) != 2 {
+				return errors.New("JSON entry is not a [key, value] pair")
+			}
+			if err := json.Unmarshal(pair[0], &k); err != nil {
+				return err
+			}
+			if err := json.Unmarshal(pair[1], &v); err != nil {
+				return err
+			}
+		}
+		add(k, v)
+	}
+
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != end {
+		return fmt.Errorf("unexpected JSON token %v, want %v", tok, end)
+	}
+	return nil
+}
+
+func encodeGob[K, V any](n int, iterate func(uskiplist.Iterator[entry[K, V]])) ([]byte, error) {
+	keys := make([]K, 0, n)
+	values := make([]V, 0, n)
+	iterate(func(e *entry[K, V]) bool {
+		keys = append(keys, e.key)
+		values = append(values, e.value)
+		return true
+	})
+
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(keys); err != nil {
+		return nil, err
+	}
+	if err := enc.Encode(values); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeGob[K, V any](data []byte, add func(k K, v V)) error {
+	var keys []K
+	var values []V
+	dec := gob.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(&keys); err != nil {
+		return err
+	}
+	if err := dec.Decode(&values); err != nil {
+		return err
+	}
+	if len(keys) != len(values) {
+		return errors.New("gob keys and values mismatch")
+	}
+
+	for i, k := range keys {
+		add(k, values[i])
+	}
+	return nil
+}
+
+// loader returns a function which adds entries to m, in O(1) while they come
+// in ascending key order.
+func (m *Map[K, V]) loader() func(k K, v V) {
+	b := NewBuilder(m, BuildOptions{})
+	return func(k K, v V) {
+		if b != nil && b.Append(k, v) {
+			return
+		}
+		b = nil
+		m.Set(k, v)
+	}
+}
+
+// MarshalJSON implements json.Marshaler. The map is encoded as an object with
+// members in ascending key order when K is a string or implements
+// encoding.TextMarshaler, otherwise as an array of [key, value] pairs. It has
+// a value receiver, so a map held by value is encoded too.
+func (m Map[K, V]) MarshalJSON() ([]byte, error) {
+	return encodeJSON(entries(m.Len(), m.list.Iterate))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it reinitializes the map, a
+// ranked map stays ranked. A later member wins for duplicate keys.
+func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	m.Init()
+	return decodeJSON(data, m.loader())
+}
+
+// GobEncode implements gob.GobEncoder, see MarshalJSON for the value
+// receiver.
+func (m Map[K, V]) GobEncode() ([]byte, error) {
+	return encodeGob(m.Len(), entries(m.Len(), m.list.Iterate))
+}
+
+// GobDecode implements gob.GobDecoder, it reinitializes the map, a ranked map
+// stays ranked.
+func (m *Map[K, V]) GobDecode(data []byte) error {
+	m.Init()
+	return decodeGob(data, m.loader())
+}
+
+// loader returns a function which adds entries to m, in O(1) while they come
+// in ascending key order.
+func (m *OrderedMap[K, V]) loader() func(k K, v V) {
+	b := NewOrderedBuilder(m, BuildOptions{})
+	return func(k K, v V) {
+		if b != nil && b.Append(k, v) {
+			return
+		}
+		b = nil
+		m.Set(k, v)
+	}
+}
+
+// MarshalJSON implements json.Marshaler. The map is encoded as an object with
+// members in ascending key order when K is a string or implements
+// encoding.TextMarshaler, otherwise as an array of [key, value] pairs. It has
+// a value receiver, so a map held by value is encoded too.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	return encodeJSON(entries(m.Len(), m.list.Iterate))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it reinitializes the map, a
+// ranked map stays ranked. A later member wins for duplicate keys.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	m.Init()
+	return decodeJSON(data, m.loader())
+}
+
+// GobEncode implements gob.GobEncoder, see MarshalJSON for the value
+// receiver.
+func (m OrderedMap[K, V]) GobEncode() ([]byte, error) {
+	return encodeGob(m.Len(), entries(m.Len(), m.list.Iterate))
+}
+
+// GobDecode implements gob.GobDecoder, it reinitializes the map, a ranked map
+// stays ranked.
+func (m *OrderedMap[K, V]) GobDecode(data []byte) error {
+	m.Init()
+	return decodeGob(data, m.loader())
+}
+
+// loader returns a function which adds entries to m, in O(1) while they come
+// in ascending key order.
+func (m *FuncMap[K, V]) loader() func(k K, v V) {
+	b := NewFuncBuilder(m, BuildOptions{})
+	return func(k K, v V) {
+		if b != nil && b.Append(k, v) {
+			return
+		}
+		b = nil
+		m.Set(k, v)
+	}
+}
+
+// MarshalJSON implements json.Marshaler. The map is encoded as an object with
+// members in ascending key order when K is a string or implements
+// encoding.TextMarshaler, otherwise as an array of [key, value] pairs. It has
+// a value receiver, so a map held by value is encoded too.
+func (m FuncMap[K, V]) MarshalJSON() ([]byte, error) {
+	return encodeJSON(entries(m.Len(), m.list.Iterate))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it reinitializes the map, a
+// ranked map stays ranked. A later member wins for duplicate keys.
+func (m *FuncMap[K, V]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if m.list.Less() == nil {
+		return ErrNoLess
+	}
+	m.Init()
+	return decodeJSON(data, m.loader())
+}
+
+// GobEncode implements gob.GobEncoder, see MarshalJSON for the value
+// receiver.
+func (m FuncMap[K, V]) GobEncode() ([]byte, error) {
+	return encodeGob(m.Len(), entries(m.Len(), m.list.Iterate))
+}
+
+// GobDecode implements gob.GobDecoder, it reinitializes the map, a ranked map
+// stays ranked.
+func (m *FuncMap[K, V]) GobDecode(data []byte) error {
+	if m.list.Less() == nil {
+		return ErrNoLess
+	}
+	m.Init()
+	return decodeGob(data, m.loader())
+}
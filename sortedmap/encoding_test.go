@@ -0,0 +1,230 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/someonegg/gocontainer/sortedmap"
+)
+
+func TestOrderedMapJSON(t *testing.T) {
+	type config struct {
+		Limits *sortedmap.OrderedMap[string, int] `json:"limits"`
+		Ports  *sortedmap.OrderedMap[int, string] `json:"ports"`
+	}
+
+	c := config{
+		Limits: sortedmap.NewOrdered[string, int](),
+		Ports:  sortedmap.NewOrdered[int, string](),
+	}
+	c.Limits.Set("b\"q", 2)
+	c.Limits.Set("a", 1)
+	c.Limits.Set("c", 3)
+	c.Ports.Set(443, "https")
+	c.Ports.Set(80, "http")
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"limits":{"a":1,"b\"q":2,"c":3},"ports":[[80,"http"],[443,"https"]]}`
+	if string(data) != want {
+		t.Fatalf("Marshal = %s, want %s", data, want)
+	}
+
+	var c2 config
+	if err := json.Unmarshal(data, &c2); err != nil {
+		t.Fatal(err)
+	}
+	data2, _ := json.Marshal(c2)
+	if string(data2) != want {
+		t.Fatalf("Marshal after Unmarshal = %s, want %s", data2, want)
+	}
+
+	// Unsorted input and duplicate keys.
+	m := sortedmap.NewOrderedRanked[string, int]()
+	if err := json.Unmarshal([]byte(`{"b":1,"a":2,"c":3,"a":4}`), m); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := json.Marshal(m); string(data) != `{"a":4,"b":1,"c":3}` {
+		t.Fatalf("Marshal unsorted = %s", data)
+	}
+	if k, _, _ := m.At(2); k != "c" {
+		t.Fatalf("At(2) = %q, want c", k)
+	}
+
+	for _, bad := range []string{`[]`, `{"a":"x"}`, `{"a":1`, `[["a",1]]`} {
+		if err := json.Unmarshal([]byte(bad), m); err == nil {
+			t.Fatalf("Unmarshal %s succeeded", bad)
+		}
+	}
+	if err := json.Unmarshal([]byte(`[[1,"a"],[2]]`), sortedmap.NewOrdered[int, string]()); err == nil {
+		t.Fatal("Unmarshal a broken pair succeeded")
+	}
+}
+
+func TestMapJSONTextKeys(t *testing.T) {
+	m := sortedmap.NewFunc[netip.Addr, bool](func(a, b netip.Addr) bool { return a.Less(b) })
+	m.Set(netip.MustParseAddr("10.0.0.2"), true)
+	m.Set(netip.MustParseAddr("10.0.0.1"), false)
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"10.0.0.1":false,"10.0.0.2":true}`; string(data) != want {
+		t.Fatalf("Marshal = %s, want %s", data, want)
+	}
+
+	m2 := sortedmap.New[netip.Addr, bool]()
+	if err := json.Unmarshal(data, m2); err != nil {
+		t.Fatal(err)
+	}
+	if v := m2.Get(netip.MustParseAddr("10.0.0.2")); v == nil || !*v {
+		t.Fatal("Unmarshal lost 10.0.0.2")
+	}
+}
+
+func TestOrderedMapGob(t *testing.T) {
+	m := sortedmap.NewOrdered[string, []int]()
+	m.Set("x", []int{1, 2})
+	m.Set("a", nil)
+	m.Set("m", []int{3})
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(m); err != nil {
+		t.Fatal(err)
+	}
+	m2 := sortedmap.NewOrdered[string, []int]()
+	if err := gob.NewDecoder(&buf).Decode(m2); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	m2.Range(func(k string, v *[]int) bool {
+		keys = append(keys, k)
+		if !reflect.DeepEqual(*v, *m.Get(k)) && len(*v)+len(*m.Get(k)) > 0 {
+			t.Fatalf("gob %q = %v, want %v", k, *v, *m.Get(k))
+		}
+		return true
+	})
+	if !reflect.DeepEqual(keys, []string{"a", "m", "x"}) {
+		t.Fatalf("gob keys = %v", keys)
+	}
+}
+
+func TestMapByValueField(t *testing.T) {
+	type doc struct {
+		Scores sortedmap.OrderedMap[string, int]
+	}
+	var d doc
+	d.Scores.Init()
+	d.Scores.Set("b", 2)
+	d.Scores.Set("a", 1)
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"Scores":{"a":1,"b":2}}`; got != want {
+		t.Fatalf("Marshal = %s, want %s", got, want)
+	}
+
+	var d2 doc
+	if err := json.Unmarshal(data, &d2); err != nil {
+		t.Fatal(err)
+	}
+	if v := d2.Scores.Get("a"); d2.Scores.Len() != 2 || v == nil || *v != 1 {
+		t.Fatalf("Unmarshal = %d entries, a = %v", d2.Scores.Len(), v)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(d); err != nil {
+		t.Fatal(err)
+	}
+	var d3 doc
+	if err := gob.NewDecoder(&buf).Decode(&d3); err != nil {
+		t.Fatal(err)
+	}
+	if v := d3.Scores.Get("b"); d3.Scores.Len() != 2 || v == nil || *v != 2 {
+		t.Fatalf("gob = %d entries, b = %v", d3.Scores.Len(), v)
+	}
+}
+
+func TestFuncMapDecodeNoLess(t *testing.T) {
+	less := func(a, b string) bool { return a < b }
+	m := sortedmap.NewFunc[string, int](less)
+	m.Set("a", 1)
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(m); err != nil {
+		t.Fatal(err)
+	}
+
+	var zero sortedmap.FuncMap[string, int]
+	if err := json.Unmarshal(data, &zero); err != sortedmap.ErrNoLess {
+		t.Fatalf("Unmarshal = %v, want ErrNoLess", err)
+	}
+	if err := zero.UnmarshalBinary(bin); err != sortedmap.ErrNoLess {
+		t.Fatalf("UnmarshalBinary = %v, want ErrNoLess", err)
+	}
+	if err := zero.GobDecode(buf.Bytes()); err != sortedmap.ErrNoLess {
+		t.Fatalf("GobDecode = %v, want ErrNoLess", err)
+	}
+}
+
+func TestZeroMapField(t *testing.T) {
+	type doc struct {
+		Scores sortedmap.OrderedMap[string, int]
+		Keys   sortedmap.Map[testKey, string]
+		Names  sortedmap.FuncMap[string, bool]
+	}
+
+	data, err := json.Marshal(doc{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"Scores":{},"Keys":[],"Names":{}}`; got != want {
+		t.Fatalf("Marshal = %s, want %s", got, want)
+	}
+
+	var d doc
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(struct {
+		Scores sortedmap.OrderedMap[string, int]
+	}{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gob.NewDecoder(&buf).Decode(&d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Scores.Len() != 0 {
+		t.Fatalf("gob Len = %d, want 0", d.Scores.Len())
+	}
+
+	var zero sortedmap.OrderedMap[string, int]
+	bin, err := zero.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := sortedmap.NewOrdered[string, int]()
+	m.Set("a", 1)
+	if err := m.UnmarshalBinary(bin); err != nil || m.Len() != 0 {
+		t.Fatalf("UnmarshalBinary = %v, Len %d, want 0", err, m.Len())
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package sortedmap
+
+import "iter"
+
+// All returns an iterator over the entries in ascending key order.
+//
+// The current entry may be deleted during iteration, and values may be changed
+// through the provided pointer.
+func (m *Map[K, V]) All() iter.Seq2[K, *V] {
+	return m.Range
+}
+
+// Keys returns an iterator over the keys in ascending order.
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.list.Iterate(func(e *entry[K, V]) bool {
+			return yield(e.key)
+		})
+	}
+}
+
+// Values returns an iterator over the values in ascending key order.
+func (m *Map[K, V]) Values() iter.Seq[*V] {
+	return func(yield func(*V) bool) {
+		m.list.Iterate(func(e *entry[K, V]) bool {
+			return yield(&e.value)
+		})
+	}
+}
+
+// Backward returns an iterator over the entries in descending key order.
+func (m *Map[K, V]) Backward() iter.Seq2[K, *V] {
+	return m.RangeReverse
+}
+
+// From returns an iterator over the entries with key greater than or equal to
+// pivot, in ascending key order.
+func (m *Map[K, V]) From(pivot K) iter.Seq2[K, *V] {
+	return func(yield func(K, *V) bool) {
+		m.RangeFrom(pivot, yield)
+	}
+}
+
+// All returns an iterator over the entries in ascending key order.
+//
+// The current entry may be deleted during iteration, and values may be changed
+// through the provided pointer.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, *V] {
+	return m.Range
+}
+
+// Keys returns an iterator over the keys in ascending order.
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.list.Iterate(func(e *entry[K, V]) bool {
+			return yield(e.key)
+		})
+	}
+}
+
+// Values returns an iterator over the values in ascending key order.
+func (m *OrderedMap[K, V]) Values() iter.Seq[*V] {
+	return func(yield func(*V) bool) {
+		m.list.Iterate(func(e *entry[K, V]) bool {
+			return yield(&e.value)
+		})
+	}
+}
+
+// Backward returns an iterator over the entries in descending key order.
+func (m *OrderedMap[K, V]) Backward() iter.Seq2[K, *V] {
+	return m.RangeReverse
+}
+
+// From returns an iterator over the entries with key greater than or equal to
+// pivot, in ascending key order.
+func (m *OrderedMap[K, V]) From(pivot K) iter.Seq2[K, *V] {
+	return func(yield func(K, *V) bool) {
+		m.RangeFrom(pivot, yield)
+	}
+}
+
+// All returns an iterator over the entries in ascending key order.
+//
+// The current entry may be deleted during iteration, and values may be changed
+// through the provided pointer.
+func (m *MultiMap[K, V]) All() iter.Seq2[K, *V] {
+	return m.Range
+}
+
+// Keys returns an iterator over the keys in ascending order.
+func (m *MultiMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.list.Iterate(func(e *entry[K, V]) bool {
+			return yield(e.key)
+		})
+	}
+}
+
+// Values returns an iterator over the values in ascending key order.
+func (m *MultiMap[K, V]) Values() iter.Seq[*V] {
+	return func(yield func(*V) bool) {
+		m.list.Iterate(func(e *entry[K, V]) bool {
+			return yield(&e.value)
+		})
+	}
+}
+
+// Backward returns an iterator over the entries in descending key order.
+func (m *MultiMap[K, V]) Backward() iter.Seq2[K, *V] {
+	return m.RangeReverse
+}
+
+// From returns an iterator over the entries with key greater than or equal to
+// pivot, in ascending key order.
+func (m *MultiMap[K, V]) From(pivot K) iter.Seq2[K, *V] {
+	return func(yield func(K, *V) bool) {
+		m.RangeFrom(pivot, yield)
+	}
+}
+
+// All returns an iterator over the entries in ascending key order.
+//
+// The current entry may be deleted during iteration, and values may be changed
+// through the provided pointer.
+func (m *OrderedMultiMap[K, V]) All() iter.Seq2[K, *V] {
+	return m.Range
+}
+
+// Keys returns an iterator over the keys in ascending order.
+func (m *OrderedMultiMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.list.Iterate(func(e *entry[K, V]) bool {
+			return yield(e.key)
+		})
+	}
+}
+
+// Values returns an iterator over the values in ascending key order.
+func (m *OrderedMultiMap[K, V]) Values() iter.Seq[*V] {
+	return func(yield func(*V) bool) {
+		m.list.Iterate(func(e *entry[K, V]) bool {
+			return yield(&e.value)
+		})
+	}
+}
+
+// Backward returns an iterator over the entries in descending key order.
+func (m *OrderedMultiMap[K, V]) Backward() iter.Seq2[K, *V] {
+	return m.RangeReverse
+}
+
+// From returns an iterator over the entries with key greater than or equal to
+// pivot, in ascending key order.
+func (m *OrderedMultiMap[K, V]) From(pivot K) iter.Seq2[K, *V] {
+	return func(yield func(K, *V) bool) {
+		m.RangeFrom(pivot, yield)
+	}
+}
+
+// All returns an iterator over the entries in ascending key order.
+//
+// The current entry may be deleted during iteration, and values may be changed
+// through the provided pointer.
+func (m *FuncMap[K, V]) All() iter.Seq2[K, *V] {
+	return m.Range
+}
+
+// Keys returns an iterator over the keys in ascending order.
+func (m *FuncMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.list.Iterate(func(e *entry[K, V]) bool {
+			return yield(e.key)
+		})
+	}
+}
+
+// Values returns an iterator over the values in ascending key order.
+func (m *FuncMap[K, V]) Values() iter.Seq[*V] {
+	return func(yield func(*V) bool) {
+		m.list.Iterate(func(e *entry[K, V]) bool {
+			return yield(&e.value)
+		})
+	}
+}
+
+// Backward returns an iterator over the entries in descending key order.
+func (m *FuncMap[K, V]) Backward() iter.Seq2[K, *V] {
+	return m.RangeReverse
+}
+
+// From returns an iterator over the entries with key greater than or equal to
+// pivot, in ascending key order.
+func (m *FuncMap[K, V]) From(pivot K) iter.Seq2[K, *V] {
+	return func(yield func(K, *V) bool) {
+		m.RangeFrom(pivot, yield)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package sortedmap_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/someonegg/gocontainer/sortedmap"
+)
+
+func TestOrderedMapIterators(t *testing.T) {
+	m := sortedmap.NewOrdered[string, int]()
+	m.Set("b", 2)
+	m.Set("a", 1)
+	m.Set("c", 3)
+
+	var keys []string
+	for k, v := range m.All() {
+		keys = append(keys, k)
+		*v *= 10
+	}
+	if !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("All keys = %v, want [a b c]", keys)
+	}
+
+	if got := slices.Collect(m.Keys()); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Fatalf("Keys = %v, want [a b c]", got)
+	}
+
+	var values []int
+	for v := range m.Values() {
+		values = append(values, *v)
+	}
+	if !reflect.DeepEqual(values, []int{10, 20, 30}) {
+		t.Fatalf("Values = %v, want [10 20 30]", values)
+	}
+
+	keys = keys[:0]
+	for k := range m.Backward() {
+		keys = append(keys, k)
+		if k == "b" {
+			break
+		}
+	}
+	if !reflect.DeepEqual(keys, []string{"c", "b"}) {
+		t.Fatalf("Backward keys = %v, want [c b]", keys)
+	}
+
+	keys = keys[:0]
+	for k := range m.From("b") {
+		keys = append(keys, k)
+		m.Delete(k)
+	}
+	if !reflect.DeepEqual(keys, []string{"b", "c"}) || m.Len() != 1 {
+		t.Fatalf("From keys = %v, Len = %d, want [b c] and 1", keys, m.Len())
+	}
+}
+
+func TestMultiMapIterators(t *testing.T) {
+	m := sortedmap.NewOrderedMulti[int, string]()
+	m.Add(1, "x")
+	m.Add(1, "y")
+	m.Add(0, "z")
+
+	var values []string
+	for _, v := range m.All() {
+		values = append(values, *v)
+	}
+	if !reflect.DeepEqual(values, []string{"z", "x", "y"}) {
+		t.Fatalf("All values = %v, want [z x y]", values)
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import (
+	"github.com/someonegg/gocontainer/cmp"
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+// The set operations walk both sides in ascending key order at the same time
+// and build the result with a Builder, so they take O(n+m) time. Merge adds
+// the entries of the other side with a search finger instead, see merge.
+
+type keyed[K, E any] interface {
+	*E
+	Key() K
+}
+
+// walk merges a and b, which are in ascending key order, calling fn with the
+// elements of each key, one of them is nil when the key is only on one side.
+func walk[K, E any, PE keyed[K, E]](a, b []*E, less func(a, b K) bool, fn func(ea, eb *E)) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ka, kb := PE(a[i]).Key(), PE(b[j]).Key()
+		switch {
+		case less(ka, kb):
+			fn(a[i], nil)
+			i++
+		case less(kb, ka):
+			fn(nil, b[j])
+			j++
+		default:
+			fn(a[i], b[j])
+			i++
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		fn(a[i], nil)
+	}
+	for ; j < len(b); j++ {
+		fn(nil, b[j])
+	}
+}
+
+// elements returns the elements of a skiplist in ascending key order.
+func elements[E any](n int, iterate func(uskiplist.Iterator[E])) []*E {
+	es := make([]*E, 0, n)
+	iterate(func(e *E) bool {
+		es = append(es, e)
+		return true
+	})
+	return es
+}
+
+// finger is the part of uskiplist.Finger used by merge.
+type finger[K, E any] interface {
+	Get(k K) *E
+	InsertOrGet(e *E) (existing *E, inserted bool)
+}
+
+// merge adds the entries of b, which are in ascending key order, through the
+// finger f of a map. The searches go from one key to the next, so it takes
+// O(k*log(n/k)) time for k entries in b and n in the map, and no entry of the
+// map is relinked.
+//
+// resolve is called for all keys in both before the map changes, so it sees
+// the map intact, and the map is unchanged when it panics. record is called
+// before each change when not nil.
+func merge[K, V any](f finger[K, entry[K, V]], b []*entry[K, V], resolve func(k K, v, other V) V, record func(k K, e *entry[K, V])) {
+	found := make([]*entry[K, V], len(b))
+	values := make([]V, len(b))
+	for i, eb := range b {
+		if ea := f.Get(eb.key); ea != nil {
+			found[i] = ea
+			values[i] = resolved(resolve, eb.key, ea.value, eb.value)
+		}
+	}
+
+	for i, eb := range b {
+		ea := found[i]
+		if record != nil {
+			record(eb.key, ea)
+		}
+		if ea != nil {
+			ea.value = values[i]
+		} else {
+			f.InsertOrGet(&entry[K, V]{key: eb.key, value: eb.value})
+		}
+	}
+}
+
+// mergeKeys adds the members of b, which are in ascending key order, through
+// the finger f of a set, see merge.
+func mergeKeys[K any](f finger[K, member[K]], b []*member[K]) {
+	for _, eb := range b {
+		if f.Get(eb.key) == nil {
+			f.InsertOrGet(&member[K]{key: eb.key})
+		}
+	}
+}
+
+// resolved returns the value for a key on both sides, the value of other wins
+// when resolve is nil.
+func resolved[K, V any](resolve func(k K, v, other V) V, k K, v, other V) V {
+	if resolve == nil {
+		return other
+	}
+	return resolve(k, v, other)
+}
+
+// empty returns an empty map with the options of m.
+func (m *Map[K, V]) empty() *Map[K, V] {
+	r := &Map[K, V]{}
+	r.list.InitWith(m.list.Options())
+	return r
+}
+
+// combine combines m and other into a new map, fn returns the entry to add for
+// each key or nil.
+func (m *Map[K, V]) combine(other *Map[K, V], fn func(ea, eb *entry[K, V]) *entry[K, V]) *Map[K, V] {
+	r := m.empty()
+	b := uskiplist.NewBuilder(&r.list, BuildOptions{})
+	walk(
+		elements(m.Len(), m.list.Iterate),
+		elements(other.Len(), other.list.Iterate),
+		keyLess[K],
+		func(ea, eb *entry[K, V]) {
+			if e := fn(ea, eb); e != nil {
+				b.Append(e)
+			}
+		})
+	return r
+}
+
+// Merge adds the entries of other into m. For a key in both, the value
+// becomes resolve(k, v, other), or the value of other when resolve is nil. The
+// address of existing values does not change.
+//
+// It takes O(k*log(n/k)) time for k entries in other and n in m. resolve is
+// called for all keys in both before m changes, so it sees m intact, and m is
+// unchanged when it panics.
+func (m *Map[K, V]) Merge(other *Map[K, V], resolve func(k K, v, other V) V) {
+	var record func(k K, e *entry[K, V])
+	if m.snaps != nil {
+		record = m.record
+	}
+	merge[K, V](m.list.Finger(), elements(other.Len(), other.list.Iterate), resolve, record)
+}
+
+// Union returns a new map with the entries of m and other. For a key in both,
+// the value is resolve(k, v, other), or the value of other when resolve is
+// nil.
+func (m *Map[K, V]) Union(other *Map[K, V], resolve func(k K, v, other V) V) *Map[K, V] {
+	return m.combine(other, func(ea, eb *entry[K, V]) *entry[K, V] {
+		switch {
+		case ea == nil:
+			return &entry[K, V]{key: eb.key, value: eb.value}
+		case eb == nil:
+			return &entry[K, V]{key: ea.key, value: ea.value}
+		}
+		return &entry[K, V]{key: ea.key, value: resolved(resolve, ea.key, ea.value, eb.value)}
+	})
+}
+
+// Intersect returns a new map with the keys in both m and other, the value is
+// resolve(k, v, other), or the value of other when resolve is nil.
+func (m *Map[K, V]) Intersect(other *Map[K, V], resolve func(k K, v, other V) V) *Map[K, V] {
+	return m.combine(other, func(ea, eb *entry[K, V]) *entry[K, V] {
+		if ea == nil || eb == nil {
+			return nil
+		}
+		return &entry[K, V]{key: ea.key, value: resolved(resolve, ea.key, ea.value, eb.value)}
+	})
+}
+
+// Difference returns a new map with the entries of m whose key is not in
+// other.
+func (m *Map[K, V]) Difference(other *Map[K, V]) *Map[K, V] {
+	return m.combine(other, func(ea, eb *entry[K, V]) *entry[K, V] {
+		if ea == nil || eb != nil {
+			return nil
+		}
+		return &entry[K, V]{key: ea.key, value: ea.value}
+	})
+}
+
+// empty returns an empty map with the options of m.
+func (m *OrderedMap[K, V]) empty() *OrderedMap[K, V] {
+	r := &OrderedMap[K, V]{}
+	r.list.InitWith(m.list.Options())
+	return r
+}
+
+// combine combines m and other into a new map, fn returns the entry to add for
+// each key or nil.
+func (m *OrderedMap[K, V]) combine(other *OrderedMap[K, V], fn func(ea, eb *entry[K, V]) *entry[K, V]) *OrderedMap[K, V] {
+	r := m.empty()
+	b := uskiplist.NewBuilderO(&r.list, BuildOptions{})
+	walk(
+		elements(m.Len(), m.list.Iterate),
+		elements(other.Len(), other.list.Iterate),
+		cmp.Less[K],
+		func(ea, eb *entry[K, V]) {
+			if e := fn(ea, eb); e != nil {
+				b.Append(e)
+			}
+		})
+	return r
+}
+
+// Merge adds the entries of other into m. For a key in both, the value
+// becomes resolve(k, v, other), or the value of other when resolve is nil. The
+// address of existing values does not change.
+//
+// It takes O(k*log(n/k)) time for k entries in other and n in m. resolve is
+// called for all keys in both before m changes, so it sees m intact, and m is
+// unchanged when it panics.
+func (m *OrderedMap[K, V]) Merge(other *OrderedMap[K, V], resolve func(k K, v, other V) V) {
+	var record func(k K, e *entry[K, V])
+	if m.snaps != nil {
+		record = m.record
+	}
+	merge[K, V](m.list.Finger(), elements(other.Len(), other.list.Iterate), resolve, record)
+}
+
+// Union returns a new map with the entries of m and other. For a key in both,
+// the value is resolve(k, v, other), or the value of other when resolve is
+// nil.
+func (m *OrderedMap[K, V]) Union(other *OrderedMap[K, V], resolve func(k K, v, other V) V) *OrderedMap[K, V] {
+	return m.combine(other, func(ea, eb *entry[K, V]) *entry[K, V] {
+		switch {
+		case ea == nil:
+			return &entry[K, V]{key: eb.key, value: eb.value}
+		case eb == nil:
+			return &entry[K, V]{key: ea.key, value: ea.value}
+		}
+		return &entry[K, V]{key: ea.key, value: resolved(resolve, ea.key, ea.value, eb.value)}
+	})
+}
+
+// Intersect returns a new map with the keys in both m and other, the value is
+// resolve(k, v, other), or the value of other when resolve is nil.
+func (m *OrderedMap[K, V]) Intersect(other *OrderedMap[K, V], resolve func(k K, v, other V) V) *OrderedMap[K, V] {
+	return m.combine(other, func(ea, eb *entry[K, V]) *entry[K, V] {
+		if ea == nil || eb == nil {
+			return nil
+		}
+		return &entry[K, V]{key: ea.key, value: resolved(resolve, ea.key, ea.value, eb.value)}
+	})
+}
+
+// Difference returns a new map with the entries of m whose key is not in
+// other.
+func (m *OrderedMap[K, V]) Difference(other *OrderedMap[K, V]) *OrderedMap[K, V] {
+	return m.combine(other, func(ea, eb *entry[K, V]) *entry[K, V] {
+		if ea == nil || eb != nil {
+			return nil
+		}
+		return &entry[K, V]{key: ea.key, value: ea.value}
+	})
+}
+
+// combine combines s and other into a new set, fn tells whether to add the
+// key of each pair of members.
+func (s *Set[K]) combine(other *Set[K], fn func(ea, eb *member[K]) bool) *Set[K] {
+	r := NewSet[K]()
+	b := uskiplist.NewBuilder(&r.list, BuildOptions{})
+	walk(
+		elements(s.Len(), s.list.Iterate),
+		elements(other.Len(), other.list.Iterate),
+		keyLess[K],
+		func(ea, eb *member[K]) {
+			if !fn(ea, eb) {
+				return
+			}
+			if ea == nil {
+				ea = eb
+			}
+			b.Append(&member[K]{key: ea.key})
+		})
+	return r
+}
+
+// Merge adds the keys of other into s, it takes O(k*log(n/k)) time for k
+// keys in other and n in s.
+func (s *Set[K]) Merge(other *Set[K]) {
+	mergeKeys[K](s.list.Finger(), elements(other.Len(), other.list.Iterate))
+}
+
+// Union returns a new set with the keys in s or other.
+func (s *Set[K]) Union(other *Set[K]) *Set[K] {
+	return s.combine(other, func(ea, eb *member[K]) bool {
+		return true
+	})
+}
+
+// Intersect returns a new set with the keys in both s and other.
+func (s *Set[K]) Intersect(other *Set[K]) *Set[K] {
+	return s.combine(other, func(ea, eb *member[K]) bool {
+		return ea != nil && eb != nil
+	})
+}
+
+// Difference returns a new set with the keys in s but not in other.
+func (s *Set[K]) Difference(other *Set[K]) *Set[K] {
+	return s.combine(other, func(ea, eb *member[K]) bool {
+		return ea != nil && eb == nil
+	})
+}
+
+// combine combines s and other into a new set, fn tells whether to add the
+// key of each pair of members.
+func (s *OrderedSet[K]) combine(other *OrderedSet[K], fn func(ea, eb *member[K]) bool) *OrderedSet[K] {
+	r := NewOrderedSet[K]()
+	b := uskiplist.NewBuilderO(&r.list, BuildOptions{})
+	walk(
+		elements(s.Len(), s.list.Iterate),
+		elements(other.Len(), other.list.Iterate),
+		cmp.Less[K],
+		func(ea, eb *member[K]) {
+			if !fn(ea, eb) {
+				return
+			}
+			if ea == nil {
+				ea = eb
+			}
+			b.Append(&member[K]{key: ea.key})
+		})
+	return r
+}
+
+// Merge adds the keys of other into s, it takes O(k*log(n/k)) time for k
+// keys in other and n in s.
+func (s *OrderedSet[K]) Merge(other *OrderedSet[K]) {
+	mergeKeys[K](s.list.Finger(), elements(other.Len(), other.list.Iterate))
+}
+
+// Union returns a new set with the keys in s or other.
+func (s *OrderedSet[K]) Union(other *OrderedSet[K]) *OrderedSet[K] {
+	return s.combine(other, func(ea, eb *member[K]) bool {
+		return true
+	})
+}
+
+// Intersect returns a new set with the keys in both s and other.
+func (s *OrderedSet[K]) Intersect(other *OrderedSet[K]) *OrderedSet[K] {
+	return s.combine(other, func(ea, eb *member[K]) bool {
+		return ea != nil && eb != nil
+	})
+}
+
+// Difference returns a new set with the keys in s but not in other.
+func (s *OrderedSet[K]) Difference(other *OrderedSet[K]) *OrderedSet[K] {
+	return s.combine(other, func(ea, eb *member[K]) bool {
+		return ea != nil && eb == nil
+	})
+}
+
+// empty returns an empty map with the options of m.
+func (m *FuncMap[K, V]) empty() *FuncMap[K, V] {
+	r := &FuncMap[K, V]{}
+	r.list.InitFunc(m.list.Less(), m.list.Options())
+	return r
+}
+
+// combine combines m and other into a new map, fn returns the entry to add for
+// each key or nil.
+func (m *FuncMap[K, V]) combine(other *FuncMap[K, V], fn func(ea, eb *entry[K, V]) *entry[K, V]) *FuncMap[K, V] {
+	r := m.empty()
+	b := uskiplist.NewBuilderF(&r.list, BuildOptions{})
+	walk(
+		elements(m.Len(), m.list.Iterate),
+		elements(other.Len(), other.list.Iterate),
+		m.list.Less(),
+		func(ea, eb *entry[K, V]) {
+			if e := fn(ea, eb); e != nil {
+				b.Append(e)
+			}
+		})
+	return r
+}
+
+// Merge adds the entries of other into m. For a key in both, the value
+// becomes resolve(k, v, other), or the value of other when resolve is nil. The
+// address of existing values does not change.
+//
+// It takes O(k*log(n/k)) time for k entries in other and n in m. resolve is
+// called for all keys in both before m changes, so it sees m intact, and m is
+// unchanged when it panics.
+func (m *FuncMap[K, V]) Merge(other *FuncMap[K, V], resolve func(k K, v, other V) V) {
+	var record func(k K, e *entry[K, V])
+	if m.snaps != nil {
+		record = m.record
+	}
+	merge[K, V](m.list.Finger(), elements(other.Len(), other.list.Iterate), resolve, record)
+}
+
+// Union returns a new map with the entries of m and other. For a key in both,
+// the value is resolve(k, v, other), or the value of other when resolve is
+// nil.
+func (m *FuncMap[K, V]) Union(other *FuncMap[K, V], resolve func(k K, v, other V) V) *FuncMap[K, V] {
+	return m.combine(other, func(ea, eb *entry[K, V]) *entry[K, V] {
+		switch {
+		case ea == nil:
+			return &entry[K, V]{key: eb.key, value: eb.value}
+		case eb == nil:
+			return &entry[K, V]{key: ea.key, value: ea.value}
+		}
+		return &entry[K, V]{key: ea.key, value: resolved(resolve, ea.key, ea.value, eb.value)}
+	})
+}
+
+// Intersect returns a new map with the keys in both m and other, the value is
+// resolve(k, v, other), or the value of other when resolve is nil.
+func (m *FuncMap[K, V]) Intersect(other *FuncMap[K, V], resolve func(k K, v, other V) V) *FuncMap[K, V] {
+	return m.combine(other, func(ea, eb *entry[K, V]) *entry[K, V] {
+		if ea == nil || eb == nil {
+			return nil
+		}
+		return &entry[K, V]{key: ea.key, value: resolved(resolve, ea.key, ea.value, eb.value)}
+	})
+}
+
+// Difference returns a new map with the entries of m whose key is not in
+// other.
+func (m *FuncMap[K, V]) Difference(other *FuncMap[K, V]) *FuncMap[K, V] {
+	return m.combine(other, func(ea, eb *entry[K, V]) *entry[K, V] {
+		if ea == nil || eb != nil {
+			return nil
+		}
+		return &entry[K, V]{key: ea.key, value: ea.value}
+	})
+}
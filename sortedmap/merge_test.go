@@ -0,0 +1,172 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/someonegg/gocontainer/sortedmap"
+)
+
+func orderedEntries(m *sortedmap.OrderedMap[int, string]) []string {
+	var got []string
+	m.Range(func(k int, v *string) bool {
+		got = append(got, fmt.Sprint(k, *v))
+		return true
+	})
+	return got
+}
+
+func TestOrderedMapSetOperations(t *testing.T) {
+	newMap := func(keys ...int) *sortedmap.OrderedMap[int, string] {
+		m := sortedmap.NewOrderedRanked[int, string]()
+		for _, k := range keys {
+			m.Set(k, "a")
+		}
+		return m
+	}
+	concat := func(k int, v, other string) string { return v + other }
+
+	a := newMap(1, 2, 4, 6)
+	b := newMap(2, 3, 6, 7)
+	b.Set(2, "b")
+	b.Set(3, "b")
+
+	if got := orderedEntries(a.Union(b, concat)); !reflect.DeepEqual(got, []string{"1a", "2ab", "3b", "4a", "6aa", "7a"}) {
+		t.Fatalf("Union = %v", got)
+	}
+	if got := orderedEntries(a.Intersect(b, nil)); !reflect.DeepEqual(got, []string{"2b", "6a"}) {
+		t.Fatalf("Intersect = %v", got)
+	}
+	if got := orderedEntries(a.Difference(b)); !reflect.DeepEqual(got, []string{"1a", "4a"}) {
+		t.Fatalf("Difference = %v", got)
+	}
+	if got := a.Union(b, nil); got.Len() != 6 {
+		t.Fatalf("Union Len = %d, want 6", got.Len())
+	} else if k, _, _ := got.At(5); k != 7 {
+		t.Fatalf("Union At(5) = %d, want 7", k)
+	}
+
+	p := a.Get(2)
+	s := a.Snapshot()
+	defer s.Close()
+	a.Merge(b, concat)
+	if got := orderedEntries(a); !reflect.DeepEqual(got, []string{"1a", "2ab", "3b", "4a", "6aa", "7a"}) {
+		t.Fatalf("Merge = %v", got)
+	}
+	if a.Get(2) != p {
+		t.Fatal("Merge changed the address of a value")
+	}
+	if v, ok := s.Get(2); !ok || v != "a" {
+		t.Fatalf("snapshot Get(2) = (%q, %v), want (a, true)", v, ok)
+	}
+	if _, ok := s.Get(3); ok || s.Len() != 4 {
+		t.Fatal("snapshot observed Merge")
+	}
+
+	a.Merge(sortedmap.NewOrdered[int, string](), nil)
+	if a.Len() != 6 {
+		t.Fatalf("Merge empty Len = %d, want 6", a.Len())
+	}
+}
+
+func TestOrderedMapMergeResolve(t *testing.T) {
+	m := sortedmap.NewOrderedRanked[int, string]()
+	for k := 0; k < 1000; k++ {
+		m.Set(k, "a")
+	}
+	other := sortedmap.NewOrdered[int, string]()
+	for _, k := range []int{-1, 10, 500, 999, 1000} {
+		other.Set(k, "b")
+	}
+
+	// resolve sees m intact
+	m.Merge(other, func(k int, v, o string) string {
+		if m.Len() != 1000 || *m.Get(k) != "a" || m.Get(-1) != nil {
+			t.Fatalf("resolve(%d) sees a changed map", k)
+		}
+		return v + o
+	})
+	if m.Len() != 1002 || *m.Get(500) != "ab" || *m.Get(-1) != "b" || *m.Get(1000) != "b" {
+		t.Fatalf("Merge Len = %d, values %q %q", m.Len(), *m.Get(500), *m.Get(-1))
+	}
+	if k, _, _ := m.At(1001); k != 1000 {
+		t.Fatalf("Merge At(1001) = %d, want 1000", k)
+	}
+
+	// a panic in resolve leaves m unchanged
+	other.Set(2000, "b")
+	want := orderedEntries(m)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("resolve did not panic")
+			}
+		}()
+		m.Merge(other, func(k int, v, o string) string {
+			if k == 999 {
+				panic("resolve")
+			}
+			return v + o
+		})
+	}()
+	if got := orderedEntries(m); !reflect.DeepEqual(got, want) {
+		t.Fatal("Merge changed the map before resolve panicked")
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	newSet := func(keys ...int) *sortedmap.OrderedSet[int] {
+		s := sortedmap.NewOrderedSet[int]()
+		for _, k := range keys {
+			s.Add(k)
+		}
+		return s
+	}
+	keys := func(s *sortedmap.OrderedSet[int]) []int {
+		got := []int{}
+		s.Range(func(k int) bool {
+			got = append(got, k)
+			return true
+		})
+		return got
+	}
+
+	a := newSet(5, 1, 3, 9)
+	b := newSet(3, 4, 9, 10)
+
+	if got := keys(a.Union(b)); !reflect.DeepEqual(got, []int{1, 3, 4, 5, 9, 10}) {
+		t.Fatalf("Union = %v", got)
+	}
+	if got := keys(a.Intersect(b)); !reflect.DeepEqual(got, []int{3, 9}) {
+		t.Fatalf("Intersect = %v", got)
+	}
+	if got := keys(a.Difference(b)); !reflect.DeepEqual(got, []int{1, 5}) {
+		t.Fatalf("Difference = %v", got)
+	}
+	a.Merge(b)
+	if got := keys(a); !reflect.DeepEqual(got, []int{1, 3, 4, 5, 9, 10}) || a.Len() != 6 {
+		t.Fatalf("Merge = %v", got)
+	}
+	if !a.Has(4) || a.Has(2) || a.Add(4) || !a.Add(2) {
+		t.Fatal("Has or Add is wrong after Merge")
+	}
+
+	s := sortedmap.NewSet[testKey]()
+	s.Add(testKey{1, 2})
+	other := sortedmap.NewSet[testKey]()
+	other.Add(testKey{1, 1})
+	other.Add(testKey{1, 2})
+	var got []testKey
+	s.Union(other).Range(func(k testKey) bool {
+		got = append(got, k)
+		return true
+	})
+	if !reflect.DeepEqual(got, []testKey{{1, 1}, {1, 2}}) {
+		t.Fatalf("Set Union = %v", got)
+	}
+}
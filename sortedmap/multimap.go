@@ -0,0 +1,268 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import (
+	"github.com/someonegg/gocontainer/cmp"
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+// MultiMap is a sorted map whose keys define their ordering with Less, and
+// may repeat.
+//
+// Entries with equal keys are kept in insertion order. The address of a value
+// is stable until the entry is deleted or the map is cleared.
+type MultiMap[K cmp.Key[K], V any] struct {
+	list uskiplist.List[K, entry[K, V], *entry[K, V]]
+}
+
+// NewMulti creates and initializes a new sorted multimap.
+func NewMulti[K cmp.Key[K], V any]() *MultiMap[K, V] {
+	m := &MultiMap[K, V]{}
+	m.Init()
+	return m
+}
+
+// Init initializes the map.
+func (m *MultiMap[K, V]) Init() {
+	m.list.InitWith(uskiplist.Options{Duplicates: true})
+}
+
+// Len returns number of entries in the map.
+func (m *MultiMap[K, V]) Len() int {
+	return m.list.Len()
+}
+
+// Clear removes all entries from the map.
+func (m *MultiMap[K, V]) Clear() {
+	m.Init()
+}
+
+// Add adds an entry after all entries with key k, and returns a pointer to the
+// stored value.
+func (m *MultiMap[K, V]) Add(k K, v V) *V {
+	e := &entry[K, V]{
+		key:   k,
+		value: v,
+	}
+	m.list.Insert(e)
+	return &e.value
+}
+
+// Get returns a pointer to the first value associated with k.
+//
+// It returns nil when k is not found. The returned pointer remains valid until
+// the entry is deleted or the map is cleared.
+func (m *MultiMap[K, V]) Get(k K) *V {
+	e := m.list.Get(k)
+	if e == nil {
+		return nil
+	}
+	return &e.value
+}
+
+// GetAll returns pointers to all values associated with k, in insertion order.
+func (m *MultiMap[K, V]) GetAll(k K) []*V {
+	var values []*V
+	m.list.IterateBetween(k, k, Closed, func(e *entry[K, V]) bool {
+		values = append(values, &e.value)
+		return true
+	})
+	return values
+}
+
+// Count returns number of entries with key k.
+func (m *MultiMap[K, V]) Count(k K) int {
+	n := 0
+	m.list.IterateBetween(k, k, Closed, func(*entry[K, V]) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// DeleteOne removes the first entry with key k and returns the removed value.
+func (m *MultiMap[K, V]) DeleteOne(k K) (old V, ok bool) {
+	_, old, ok = popped(m.list.Remove(k))
+	return old, ok
+}
+
+// DeleteAll removes all entries with key k and returns the number of removed
+// entries.
+func (m *MultiMap[K, V]) DeleteAll(k K) int {
+	return m.list.DeleteRange(k, k, Closed)
+}
+
+// Range calls fn once for each entry in ascending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *MultiMap[K, V]) Range(fn func(K, *V) bool) {
+	m.list.Iterate(func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// RangeFrom calls fn once for each entry with key greater than or equal to
+// pivot, in ascending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *MultiMap[K, V]) RangeFrom(pivot K, fn func(K, *V) bool) {
+	m.list.IterateFrom(pivot, func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// RangeReverse calls fn once for each entry in descending key order, entries
+// with equal keys are visited in reverse insertion order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *MultiMap[K, V]) RangeReverse(fn func(K, *V) bool) {
+	m.list.IterateReverse(func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// RangeBefore calls fn once for each entry with key less than pivot, in
+// descending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *MultiMap[K, V]) RangeBefore(pivot K, fn func(K, *V) bool) {
+	m.list.IterateBefore(pivot, func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// OrderedMultiMap is a sorted map whose keys are ordered with the < operator,
+// and may repeat.
+//
+// Entries with equal keys are kept in insertion order. The address of a value
+// is stable until the entry is deleted or the map is cleared.
+type OrderedMultiMap[K cmp.Ordered, V any] struct {
+	list uskiplist.ListO[K, entry[K, V], *entry[K, V]]
+}
+
+// NewOrderedMulti creates and initializes a new sorted multimap for ordered keys.
+func NewOrderedMulti[K cmp.Ordered, V any]() *OrderedMultiMap[K, V] {
+	m := &OrderedMultiMap[K, V]{}
+	m.Init()
+	return m
+}
+
+// Init initializes the map.
+func (m *OrderedMultiMap[K, V]) Init() {
+	m.list.InitWith(uskiplist.Options{Duplicates: true})
+}
+
+// Len returns number of entries in the map.
+func (m *OrderedMultiMap[K, V]) Len() int {
+	return m.list.Len()
+}
+
+// Clear removes all entries from the map.
+func (m *OrderedMultiMap[K, V]) Clear() {
+	m.Init()
+}
+
+// Add adds an entry after all entries with key k, and returns a pointer to the
+// stored value.
+func (m *OrderedMultiMap[K, V]) Add(k K, v V) *V {
+	e := &entry[K, V]{
+		key:   k,
+		value: v,
+	}
+	m.list.Insert(e)
+	return &e.value
+}
+
+// Get returns a pointer to the first value associated with k.
+//
+// It returns nil when k is not found. The returned pointer remains valid until
+// the entry is deleted or the map is cleared.
+func (m *OrderedMultiMap[K, V]) Get(k K) *V {
+	e := m.list.Get(k)
+	if e == nil {
+		return nil
+	}
+	return &e.value
+}
+
+// GetAll returns pointers to all values associated with k, in insertion order.
+func (m *OrderedMultiMap[K, V]) GetAll(k K) []*V {
+	var values []*V
+	m.list.IterateBetween(k, k, Closed, func(e *entry[K, V]) bool {
+		values = append(values, &e.value)
+		return true
+	})
+	return values
+}
+
+// Count returns number of entries with key k.
+func (m *OrderedMultiMap[K, V]) Count(k K) int {
+	n := 0
+	m.list.IterateBetween(k, k, Closed, func(*entry[K, V]) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// DeleteOne removes the first entry with key k and returns the removed value.
+func (m *OrderedMultiMap[K, V]) DeleteOne(k K) (old V, ok bool) {
+	_, old, ok = popped(m.list.Remove(k))
+	return old, ok
+}
+
+// DeleteAll removes all entries with key k and returns the number of removed
+// entries.
+func (m *OrderedMultiMap[K, V]) DeleteAll(k K) int {
+	return m.list.DeleteRange(k, k, Closed)
+}
+
+// Range calls fn once for each entry in ascending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *OrderedMultiMap[K, V]) Range(fn func(K, *V) bool) {
+	m.list.Iterate(func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// RangeFrom calls fn once for each entry with key greater than or equal to
+// pivot, in ascending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *OrderedMultiMap[K, V]) RangeFrom(pivot K, fn func(K, *V) bool) {
+	m.list.IterateFrom(pivot, func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// RangeReverse calls fn once for each entry in descending key order, entries
+// with equal keys are visited in reverse insertion order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *OrderedMultiMap[K, V]) RangeReverse(fn func(K, *V) bool) {
+	m.list.IterateReverse(func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// RangeBefore calls fn once for each entry with key less than pivot, in
+// descending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *OrderedMultiMap[K, V]) RangeBefore(pivot K, fn func(K, *V) bool) {
+	m.list.IterateBefore(pivot, func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
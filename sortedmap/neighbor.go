@@ -0,0 +1,193 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+func found[K, V any](e *entry[K, V]) (k K, v *V, ok bool) {
+	if e == nil {
+		return k, nil, false
+	}
+	return e.key, &e.value, true
+}
+
+func popped[K, V any](e *entry[K, V]) (k K, v V, ok bool) {
+	if e == nil {
+		return k, v, false
+	}
+	return e.key, e.value, true
+}
+
+// First returns the entry with the smallest key, or false when the map is
+// empty.
+func (m *Map[K, V]) First() (K, *V, bool) {
+	return found(m.list.First())
+}
+
+// Last returns the entry with the largest key, or false when the map is
+// empty.
+func (m *Map[K, V]) Last() (K, *V, bool) {
+	return found(m.list.Last())
+}
+
+// Floor returns the entry with the largest key less than or equal to k, or
+// false when there is none.
+func (m *Map[K, V]) Floor(k K) (K, *V, bool) {
+	return found(m.list.Floor(k))
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to k,
+// or false when there is none.
+func (m *Map[K, V]) Ceiling(k K) (K, *V, bool) {
+	return found(m.list.Ceiling(k))
+}
+
+// Lower returns the entry with the largest key less than k, or false when
+// there is none.
+func (m *Map[K, V]) Lower(k K) (K, *V, bool) {
+	return found(m.list.Lower(k))
+}
+
+// Higher returns the entry with the smallest key greater than k, or false
+// when there is none.
+func (m *Map[K, V]) Higher(k K) (K, *V, bool) {
+	return found(m.list.Higher(k))
+}
+
+// PopFirst removes the entry with the smallest key and returns it, or false
+// when the map is empty.
+func (m *Map[K, V]) PopFirst() (K, V, bool) {
+	if m.snaps != nil {
+		if e := m.list.First(); e != nil {
+			m.record(e.key, e)
+		}
+	}
+	return popped(m.list.PopFirst())
+}
+
+// PopLast removes the entry with the largest key and returns it, or false
+// when the map is empty.
+func (m *Map[K, V]) PopLast() (K, V, bool) {
+	if m.snaps != nil {
+		if e := m.list.Last(); e != nil {
+			m.record(e.key, e)
+		}
+	}
+	return popped(m.list.PopLast())
+}
+
+// First returns the entry with the smallest key, or false when the map is
+// empty.
+func (m *OrderedMap[K, V]) First() (K, *V, bool) {
+	return found(m.list.First())
+}
+
+// Last returns the entry with the largest key, or false when the map is
+// empty.
+func (m *OrderedMap[K, V]) Last() (K, *V, bool) {
+	return found(m.list.Last())
+}
+
+// Floor returns the entry with the largest key less than or equal to k, or
+// false when there is none.
+func (m *OrderedMap[K, V]) Floor(k K) (K, *V, bool) {
+	return found(m.list.Floor(k))
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to k,
+// or false when there is none.
+func (m *OrderedMap[K, V]) Ceiling(k K) (K, *V, bool) {
+	return found(m.list.Ceiling(k))
+}
+
+// Lower returns the entry with the largest key less than k, or false when
+// there is none.
+func (m *OrderedMap[K, V]) Lower(k K) (K, *V, bool) {
+	return found(m.list.Lower(k))
+}
+
+// Higher returns the entry with the smallest key greater than k, or false
+// when there is none.
+func (m *OrderedMap[K, V]) Higher(k K) (K, *V, bool) {
+	return found(m.list.Higher(k))
+}
+
+// PopFirst removes the entry with the smallest key and returns it, or false
+// when the map is empty.
+func (m *OrderedMap[K, V]) PopFirst() (K, V, bool) {
+	if m.snaps != nil {
+		if e := m.list.First(); e != nil {
+			m.record(e.key, e)
+		}
+	}
+	return popped(m.list.PopFirst())
+}
+
+// PopLast removes the entry with the largest key and returns it, or false
+// when the map is empty.
+func (m *OrderedMap[K, V]) PopLast() (K, V, bool) {
+	if m.snaps != nil {
+		if e := m.list.Last(); e != nil {
+			m.record(e.key, e)
+		}
+	}
+	return popped(m.list.PopLast())
+}
+
+// First returns the entry with the smallest key, or false when the map is
+// empty.
+func (m *FuncMap[K, V]) First() (K, *V, bool) {
+	return found(m.list.First())
+}
+
+// Last returns the entry with the largest key, or false when the map is
+// empty.
+func (m *FuncMap[K, V]) Last() (K, *V, bool) {
+	return found(m.list.Last())
+}
+
+// Floor returns the entry with the largest key less than or equal to k, or
+// false when there is none.
+func (m *FuncMap[K, V]) Floor(k K) (K, *V, bool) {
+	return found(m.list.Floor(k))
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to k,
+// or false when there is none.
+func (m *FuncMap[K, V]) Ceiling(k K) (K, *V, bool) {
+	return found(m.list.Ceiling(k))
+}
+
+// Lower returns the entry with the largest key less than k, or false when
+// there is none.
+func (m *FuncMap[K, V]) Lower(k K) (K, *V, bool) {
+	return found(m.list.Lower(k))
+}
+
+// Higher returns the entry with the smallest key greater than k, or false
+// when there is none.
+func (m *FuncMap[K, V]) Higher(k K) (K, *V, bool) {
+	return found(m.list.Higher(k))
+}
+
+// PopFirst removes the entry with the smallest key and returns it, or false
+// when the map is empty.
+func (m *FuncMap[K, V]) PopFirst() (K, V, bool) {
+	if m.snaps != nil {
+		if e := m.list.First(); e != nil {
+			m.record(e.key, e)
+		}
+	}
+	return popped(m.list.PopFirst())
+}
+
+// PopLast removes the entry with the largest key and returns it, or false
+// when the map is empty.
+func (m *FuncMap[K, V]) PopLast() (K, V, bool) {
+	if m.snaps != nil {
+		if e := m.list.Last(); e != nil {
+			m.record(e.key, e)
+		}
+	}
+	return popped(m.list.PopLast())
+}
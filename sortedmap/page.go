@@ -0,0 +1,99 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+// Entry is a copy of a key and its value.
+type Entry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// pager collects a page of entries from a skiplist iteration.
+type pager[K, V any] struct {
+	entries []Entry[K, V]
+	limit   int
+	more    bool
+}
+
+// newPager panics when limit is not positive, an empty page with more entries
+// behind it would never advance.
+func newPager[K, V any](limit int) *pager[K, V] {
+	if limit <= 0 {
+		panic("limit <= 0")
+	}
+	return &pager[K, V]{limit: limit}
+}
+
+func (p *pager[K, V]) add(e *entry[K, V]) bool {
+	if len(p.entries) >= p.limit {
+		p.more = true
+		return false
+	}
+	p.entries = append(p.entries, Entry[K, V]{e.key, e.value})
+	return true
+}
+
+// page returns the result of a page, next stays start when the page is empty.
+func (p *pager[K, V]) page(start K) (entries []Entry[K, V], next K, more bool) {
+	next = start
+	if len(p.entries) > 0 {
+		next = p.entries[len(p.entries)-1].Key
+	}
+	return p.entries, next, p.more
+}
+
+// FirstPage returns up to limit entries from the smallest key in ascending
+// order, see Page. It panics when limit <= 0.
+func (m *Map[K, V]) FirstPage(limit int) (entries []Entry[K, V], next K, more bool) {
+	p := newPager[K, V](limit)
+	m.list.Iterate(p.add)
+	return p.page(next)
+}
+
+// Page returns up to limit entries with key greater than after in ascending
+// order. next is the key of the last entry returned (after when none), to be
+// passed as after for the next page, and more tells whether there are entries
+// behind it. It panics when limit <= 0.
+func (m *Map[K, V]) Page(after K, limit int) (entries []Entry[K, V], next K, more bool) {
+	p := newPager[K, V](limit)
+	m.list.IterateAfter(after, p.add)
+	return p.page(after)
+}
+
+// FirstPage returns up to limit entries from the smallest key in ascending
+// order, see Page. It panics when limit <= 0.
+func (m *OrderedMap[K, V]) FirstPage(limit int) (entries []Entry[K, V], next K, more bool) {
+	p := newPager[K, V](limit)
+	m.list.Iterate(p.add)
+	return p.page(next)
+}
+
+// Page returns up to limit entries with key greater than after in ascending
+// order. next is the key of the last entry returned (after when none), to be
+// passed as after for the next page, and more tells whether there are entries
+// behind it. It panics when limit <= 0.
+func (m *OrderedMap[K, V]) Page(after K, limit int) (entries []Entry[K, V], next K, more bool) {
+	p := newPager[K, V](limit)
+	m.list.IterateAfter(after, p.add)
+	return p.page(after)
+}
+
+// FirstPage returns up to limit entries from the smallest key in ascending
+// order, see Page. It panics when limit <= 0.
+func (m *FuncMap[K, V]) FirstPage(limit int) (entries []Entry[K, V], next K, more bool) {
+	p := newPager[K, V](limit)
+	m.list.Iterate(p.add)
+	return p.page(next)
+}
+
+// Page returns up to limit entries with key greater than after in ascending
+// order. next is the key of the last entry returned (after when none), to be
+// passed as after for the next page, and more tells whether there are entries
+// behind it. It panics when limit <= 0.
+func (m *FuncMap[K, V]) Page(after K, limit int) (entries []Entry[K, V], next K, more bool) {
+	p := newPager[K, V](limit)
+	m.list.IterateAfter(after, p.add)
+	return p.page(after)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+// At returns the entry at index i (0-based) in ascending key order.
+//
+// It returns false when i is out of range. It takes O(log n) for a ranked map,
+// and O(n) otherwise.
+func (m *Map[K, V]) At(i int) (k K, v *V, ok bool) {
+	return found(m.list.At(i))
+}
+
+// IndexOf returns the index (0-based) of k in ascending key order, or -1 when
+// k is not found. It takes O(log n) for a ranked map, and O(n) otherwise.
+func (m *Map[K, V]) IndexOf(k K) int {
+	return m.list.IndexOf(k)
+}
+
+// RangeIndex calls fn once for each entry with index in [lo, hi), in ascending
+// key order. Locating lo takes O(log n) for a ranked map, and O(n) otherwise.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *Map[K, V]) RangeIndex(lo, hi int, fn func(K, *V) bool) {
+	if lo < 0 {
+		lo = 0
+	}
+	n := hi - lo
+	if n <= 0 {
+		return
+	}
+	m.list.IterateAt(lo, func(e *entry[K, V]) bool {
+		n--
+		return fn(e.key, &e.value) && n > 0
+	})
+}
+
+// At returns the entry at index i (0-based) in ascending key order.
+//
+// It returns false when i is out of range. It takes O(log n) for a ranked map,
+// and O(n) otherwise.
+func (m *OrderedMap[K, V]) At(i int) (k K, v *V, ok bool) {
+	return found(m.list.At(i))
+}
+
+// IndexOf returns the index (0-based) of k in ascending key order, or -1 when
+// k is not found. It takes O(log n) for a ranked map, and O(n) otherwise.
+func (m *OrderedMap[K, V]) IndexOf(k K) int {
+	return m.list.IndexOf(k)
+}
+
+// RangeIndex calls fn once for each entry with index in [lo, hi), in ascending
+// key order. Locating lo takes O(log n) for a ranked map, and O(n) otherwise.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *OrderedMap[K, V]) RangeIndex(lo, hi int, fn func(K, *V) bool) {
+	if lo < 0 {
+		lo = 0
+	}
+	n := hi - lo
+	if n <= 0 {
+		return
+	}
+	m.list.IterateAt(lo, func(e *entry[K, V]) bool {
+		n--
+		return fn(e.key, &e.value) && n > 0
+	})
+}
+
+// At returns the entry at index i (0-based) in ascending key order.
+//
+// It returns false when i is out of range. It takes O(log n) for a ranked map,
+// and O(n) otherwise.
+func (m *FuncMap[K, V]) At(i int) (k K, v *V, ok bool) {
+	return found(m.list.At(i))
+}
+
+// IndexOf returns the index (0-based) of k in ascending key order, or -1 when
+// k is not found. It takes O(log n) for a ranked map, and O(n) otherwise.
+func (m *FuncMap[K, V]) IndexOf(k K) int {
+	return m.list.IndexOf(k)
+}
+
+// RangeIndex calls fn once for each entry with index in [lo, hi), in ascending
+// key order. Locating lo takes O(log n) for a ranked map, and O(n) otherwise.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *FuncMap[K, V]) RangeIndex(lo, hi int, fn func(K, *V) bool) {
+	if lo < 0 {
+		lo = 0
+	}
+	n := hi - lo
+	if n <= 0 {
+		return
+	}
+	m.list.IterateAt(lo, func(e *entry[K, V]) bool {
+		n--
+		return fn(e.key, &e.value) && n > 0
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import "math/rand"
+
+func sampled[K, V any](elems []*entry[K, V]) []Entry[K, V] {
+	if elems == nil {
+		return nil
+	}
+	entries := make([]Entry[K, V], len(elems))
+	for i, e := range elems {
+		entries[i] = Entry[K, V]{e.key, e.value}
+	}
+	return entries
+}
+
+// Quantile returns the entry at about index q*(Len-1), or false when the map
+// is empty. It is exact in a ranked map, see uskiplist.List.Quantile for the
+// error bounds otherwise.
+func (m *Map[K, V]) Quantile(q float64) (K, *V, bool) {
+	return found(m.list.Quantile(q))
+}
+
+// SampleN returns n entries in ascending key order at about evenly spaced
+// indexes, the first entry included. It is exact in a ranked map, see
+// uskiplist.List.SampleN for the error bounds otherwise.
+func (m *Map[K, V]) SampleN(n int) []Entry[K, V] {
+	return sampled(m.list.SampleN(n))
+}
+
+// RandomSample returns n entries chosen uniformly at random in ascending key
+// order, r makes the choices, see uskiplist.List.RandomSample.
+func (m *Map[K, V]) RandomSample(n int, r *rand.Rand) []Entry[K, V] {
+	return sampled(m.list.RandomSample(n, r))
+}
+
+// Quantile returns the entry at about index q*(Len-1), or false when the map
+// is empty. It is exact in a ranked map, see uskiplist.List.Quantile for the
+// error bounds otherwise.
+func (m *OrderedMap[K, V]) Quantile(q float64) (K, *V, bool) {
+	return found(m.list.Quantile(q))
+}
+
+// SampleN returns n entries in ascending key order at about evenly spaced
+// indexes, the first entry included. It is exact in a ranked map, see
+// uskiplist.List.SampleN for the error bounds otherwise.
+func (m *OrderedMap[K, V]) SampleN(n int) []Entry[K, V] {
+	return sampled(m.list.SampleN(n))
+}
+
+// RandomSample returns n entries chosen uniformly at random in ascending key
+// order, r makes the choices, see uskiplist.List.RandomSample.
+func (m *OrderedMap[K, V]) RandomSample(n int, r *rand.Rand) []Entry[K, V] {
+	return sampled(m.list.RandomSample(n, r))
+}
+
+// Quantile returns the entry at about index q*(Len-1), or false when the map
+// is empty. It is exact in a ranked map, see uskiplist.List.Quantile for the
+// error bounds otherwise.
+func (m *FuncMap[K, V]) Quantile(q float64) (K, *V, bool) {
+	return found(m.list.Quantile(q))
+}
+
+// SampleN returns n entries in ascending key order at about evenly spaced
+// indexes, the first entry included. It is exact in a ranked map, see
+// uskiplist.List.SampleN for the error bounds otherwise.
+func (m *FuncMap[K, V]) SampleN(n int) []Entry[K, V] {
+	return sampled(m.list.SampleN(n))
+}
+
+// RandomSample returns n entries chosen uniformly at random in ascending key
+// order, r makes the choices, see uskiplist.List.RandomSample.
+func (m *FuncMap[K, V]) RandomSample(n int, r *rand.Rand) []Entry[K, V] {
+	return sampled(m.list.RandomSample(n, r))
+}
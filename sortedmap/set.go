@@ -0,0 +1,177 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import (
+	"github.com/someonegg/gocontainer/cmp"
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+type member[K any] struct {
+	uskiplist.Embedder[member[K]]
+
+	key K
+}
+
+func (e *member[K]) Key() K {
+	return e.key
+}
+
+func foundKey[K any](e *member[K]) (k K, ok bool) {
+	if e == nil {
+		return k, false
+	}
+	return e.key, true
+}
+
+// Set is a sorted set whose keys define their ordering with Less.
+//
+// The keys are linked into a skiplist directly, without an entry for a value.
+type Set[K cmp.Key[K]] struct {
+	list uskiplist.List[K, member[K], *member[K]]
+}
+
+// NewSet creates and initializes a new sorted set.
+func NewSet[K cmp.Key[K]]() *Set[K] {
+	s := &Set[K]{}
+	s.Init()
+	return s
+}
+
+// Init initializes the set.
+func (s *Set[K]) Init() {
+	s.list.Init()
+}
+
+// Len returns number of keys in the set.
+func (s *Set[K]) Len() int {
+	return s.list.Len()
+}
+
+// Add adds k to the set, it returns false when k already exists.
+func (s *Set[K]) Add(k K) bool {
+	_, inserted := s.list.InsertOrGet(&member[K]{key: k})
+	return inserted
+}
+
+// Has tells whether k is in the set.
+func (s *Set[K]) Has(k K) bool {
+	return s.list.Get(k) != nil
+}
+
+// Remove removes k from the set, it returns false when k is not found.
+func (s *Set[K]) Remove(k K) bool {
+	return s.list.Remove(k) != nil
+}
+
+// Clear removes all keys from the set.
+func (s *Set[K]) Clear() {
+	s.Init()
+}
+
+// First returns the smallest key, or false when the set is empty.
+func (s *Set[K]) First() (K, bool) {
+	return foundKey(s.list.First())
+}
+
+// Last returns the largest key, or false when the set is empty.
+func (s *Set[K]) Last() (K, bool) {
+	return foundKey(s.list.Last())
+}
+
+// Range calls fn once for each key in ascending order.
+//
+// It stops whenever fn returns false. The current key may be removed during
+// iteration.
+func (s *Set[K]) Range(fn func(K) bool) {
+	s.list.Iterate(func(e *member[K]) bool {
+		return fn(e.key)
+	})
+}
+
+// RangeFrom calls fn once for each key greater than or equal to pivot, in
+// ascending order.
+//
+// It stops whenever fn returns false. The current key may be removed during
+// iteration.
+func (s *Set[K]) RangeFrom(pivot K, fn func(K) bool) {
+	s.list.IterateFrom(pivot, func(e *member[K]) bool {
+		return fn(e.key)
+	})
+}
+
+// OrderedSet is a sorted set whose keys are ordered with the < operator.
+type OrderedSet[K cmp.Ordered] struct {
+	list uskiplist.ListO[K, member[K], *member[K]]
+}
+
+// NewOrderedSet creates and initializes a new sorted set for ordered keys.
+func NewOrderedSet[K cmp.Ordered]() *OrderedSet[K] {
+	s := &OrderedSet[K]{}
+	s.Init()
+	return s
+}
+
+// Init initializes the set.
+func (s *OrderedSet[K]) Init() {
+	s.list.Init()
+}
+
+// Len returns number of keys in the set.
+func (s *OrderedSet[K]) Len() int {
+	return s.list.Len()
+}
+
+// Add adds k to the set, it returns false when k already exists.
+func (s *OrderedSet[K]) Add(k K) bool {
+	_, inserted := s.list.InsertOrGet(&member[K]{key: k})
+	return inserted
+}
+
+// Has tells whether k is in the set.
+func (s *OrderedSet[K]) Has(k K) bool {
+	return s.list.Get(k) != nil
+}
+
+// Remove removes k from the set, it returns false when k is not found.
+func (s *OrderedSet[K]) Remove(k K) bool {
+	return s.list.Remove(k) != nil
+}
+
+// Clear removes all keys from the set.
+func (s *OrderedSet[K]) Clear() {
+	s.Init()
+}
+
+// First returns the smallest key, or false when the set is empty.
+func (s *OrderedSet[K]) First() (K, bool) {
+	return foundKey(s.list.First())
+}
+
+// Last returns the largest key, or false when the set is empty.
+func (s *OrderedSet[K]) Last() (K, bool) {
+	return foundKey(s.list.Last())
+}
+
+// Range calls fn once for each key in ascending order.
+//
+// It stops whenever fn returns false. The current key may be removed during
+// iteration.
+func (s *OrderedSet[K]) Range(fn func(K) bool) {
+	s.list.Iterate(func(e *member[K]) bool {
+		return fn(e.key)
+	})
+}
+
+// RangeFrom calls fn once for each key greater than or equal to pivot, in
+// ascending order.
+//
+// It stops whenever fn returns false. The current key may be removed during
+// iteration.
+func (s *OrderedSet[K]) RangeFrom(pivot K, fn func(K) bool) {
+	s.list.IterateFrom(pivot, func(e *member[K]) bool {
+		return fn(e.key)
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/someonegg/gocontainer/sortedmap"
+)
+
+func TestOrderedSet(t *testing.T) {
+	s := sortedmap.NewOrderedSet[string]()
+
+	if _, ok := s.First(); ok {
+		t.Fatal("First of empty set found a key")
+	}
+	if _, ok := s.Last(); ok {
+		t.Fatal("Last of empty set found a key")
+	}
+
+	for _, k := range []string{"d", "b", "a", "c", "e"} {
+		if !s.Add(k) {
+			t.Fatalf("Add(%q) = false", k)
+		}
+	}
+	if s.Add("c") {
+		t.Fatal("Add existing key = true")
+	}
+	if s.Len() != 5 {
+		t.Fatalf("Len = %d, want 5", s.Len())
+	}
+
+	if !s.Remove("d") || s.Remove("d") || s.Has("d") || !s.Has("c") {
+		t.Fatal("Remove or Has is wrong")
+	}
+	if k, ok := s.First(); !ok || k != "a" {
+		t.Fatalf("First = (%q, %v), want (a, true)", k, ok)
+	}
+	if k, ok := s.Last(); !ok || k != "e" {
+		t.Fatalf("Last = (%q, %v), want (e, true)", k, ok)
+	}
+
+	var keys []string
+	s.RangeFrom("bb", func(k string) bool {
+		keys = append(keys, k)
+		s.Remove(k)
+		return true
+	})
+	if !reflect.DeepEqual(keys, []string{"c", "e"}) {
+		t.Fatalf("RangeFrom(bb) = %v, want [c e]", keys)
+	}
+
+	keys = keys[:0]
+	s.Range(func(k string) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if !reflect.DeepEqual(keys, []string{"a", "b"}) {
+		t.Fatalf("Range = %v, want [a b]", keys)
+	}
+
+	s.Clear()
+	if s.Len() != 0 || s.Has("a") {
+		t.Fatal("Clear left keys behind")
+	}
+}
+
+func TestSet(t *testing.T) {
+	s := sortedmap.NewSet[testKey]()
+	s.Add(testKey{2, 1})
+	s.Add(testKey{1, 9})
+	s.Add(testKey{2, 0})
+
+	if k, ok := s.First(); !ok || k != (testKey{1, 9}) {
+		t.Fatalf("First = (%v, %v)", k, ok)
+	}
+	if k, ok := s.Last(); !ok || k != (testKey{2, 1}) {
+		t.Fatalf("Last = (%v, %v)", k, ok)
+	}
+
+	var keys []testKey
+	s.RangeFrom(testKey{2, 0}, func(k testKey) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if !reflect.DeepEqual(keys, []testKey{{2, 0}, {2, 1}}) {
+		t.Fatalf("RangeFrom = %v", keys)
+	}
+	if !s.Remove(testKey{2, 0}) || s.Len() != 2 {
+		t.Fatal("Remove is wrong")
+	}
+}
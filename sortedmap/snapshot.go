@@ -0,0 +1,449 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import (
+	"sync"
+
+	"github.com/someonegg/gocontainer/cmp"
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+// sorted is implemented by the sorted maps and the overlays of snapshots.
+type sorted[K, V any] interface {
+	find(k K) *entry[K, V]
+	scan(pivot *K, after bool, fn func(*entry[K, V]) bool)
+}
+
+// saved is the state of a key when the snapshot was taken.
+type saved[V any] struct {
+	value   V
+	present bool
+}
+
+// overlay holds the saved states of a snapshot.
+type overlay[K, V any] interface {
+	sorted[K, saved[V]]
+	insert(e *entry[K, saved[V]])
+}
+
+type keyOverlay[K cmp.Key[K], V any] struct {
+	list uskiplist.List[K, entry[K, saved[V]], *entry[K, saved[V]]]
+}
+
+func newKeyOverlay[K cmp.Key[K], V any]() *keyOverlay[K, V] {
+	o := &keyOverlay[K, V]{}
+	o.list.Init()
+	return o
+}
+
+func (o *keyOverlay[K, V]) find(k K) *entry[K, saved[V]] {
+	return o.list.Get(k)
+}
+
+func (o *keyOverlay[K, V]) scan(pivot *K, after bool, fn func(*entry[K, saved[V]]) bool) {
+	switch {
+	case pivot == nil:
+		o.list.Iterate(fn)
+	case after:
+		o.list.IterateAfter(*pivot, fn)
+	default:
+		o.list.IterateFrom(*pivot, fn)
+	}
+}
+
+func (o *keyOverlay[K, V]) insert(e *entry[K, saved[V]]) {
+	o.list.Insert(e)
+}
+
+type orderedOverlay[K cmp.Ordered, V any] struct {
+	list uskiplist.ListO[K, entry[K, saved[V]], *entry[K, saved[V]]]
+}
+
+func newOrderedOverlay[K cmp.Ordered, V any]() *orderedOverlay[K, V] {
+	o := &orderedOverlay[K, V]{}
+	o.list.Init()
+	return o
+}
+
+func (o *orderedOverlay[K, V]) find(k K) *entry[K, saved[V]] {
+	return o.list.Get(k)
+}
+
+func (o *orderedOverlay[K, V]) scan(pivot *K, after bool, fn func(*entry[K, saved[V]]) bool) {
+	switch {
+	case pivot == nil:
+		o.list.Iterate(fn)
+	case after:
+		o.list.IterateAfter(*pivot, fn)
+	default:
+		o.list.IterateFrom(*pivot, fn)
+	}
+}
+
+func (o *orderedOverlay[K, V]) insert(e *entry[K, saved[V]]) {
+	o.list.Insert(e)
+}
+
+type funcOverlay[K, V any] struct {
+	list uskiplist.ListF[K, entry[K, saved[V]], *entry[K, saved[V]]]
+}
+
+func newFuncOverlay[K, V any](less func(a, b K) bool) *funcOverlay[K, V] {
+	o := &funcOverlay[K, V]{}
+	o.list.InitFunc(less, uskiplist.Options{})
+	return o
+}
+
+func (o *funcOverlay[K, V]) find(k K) *entry[K, saved[V]] {
+	return o.list.Get(k)
+}
+
+func (o *funcOverlay[K, V]) scan(pivot *K, after bool, fn func(*entry[K, saved[V]]) bool) {
+	switch {
+	case pivot == nil:
+		o.list.Iterate(fn)
+	case after:
+		o.list.IterateAfter(*pivot, fn)
+	default:
+		o.list.IterateFrom(*pivot, fn)
+	}
+}
+
+func (o *funcOverlay[K, V]) insert(e *entry[K, saved[V]]) {
+	o.list.Insert(e)
+}
+
+// Snapshot is a read-only, point-in-time view of a sorted map.
+//
+// Taking a snapshot is O(1). Afterwards, the first change of each key saves
+// the previous state of the key into the open snapshots, so the cost of a
+// snapshot is proportional to the modifications made while it is open. Values
+// changed through pointers returned by the map are not tracked.
+//
+// A snapshot should be closed when it is no longer needed, because an open
+// snapshot slows down the writes of its map. It must not be used after Close.
+//
+// A snapshot of a Sync map is safe for concurrent use with the map, the others
+// must be used by the goroutine which owns the map.
+type Snapshot[K, V any] struct {
+	mu      *sync.RWMutex
+	live    sorted[K, V]
+	overlay overlay[K, V]
+	less    func(a, b K) bool
+	len     int
+	detach  func(*Snapshot[K, V])
+}
+
+// record saves the state of k, e is the current entry of k or nil.
+func (s *Snapshot[K, V]) record(k K, e *entry[K, V]) {
+	if s.overlay.find(k) != nil {
+		return
+	}
+
+	o := &entry[K, saved[V]]{key: k}
+	if e != nil {
+		o.value = saved[V]{e.value, true}
+	}
+	s.overlay.insert(o)
+}
+
+func (s *Snapshot[K, V]) rlock() {
+	if s.mu != nil {
+		s.mu.RLock()
+	}
+}
+
+func (s *Snapshot[K, V]) runlock() {
+	if s.mu != nil {
+		s.mu.RUnlock()
+	}
+}
+
+// Len returns number of entries in the snapshot.
+func (s *Snapshot[K, V]) Len() int {
+	return s.len
+}
+
+// Get returns the value associated with k, or false when k is not found.
+func (s *Snapshot[K, V]) Get(k K) (v V, ok bool) {
+	s.rlock()
+	defer s.runlock()
+
+	if e := s.overlay.find(k); e != nil {
+		return e.value.value, e.value.present
+	}
+	if e := s.live.find(k); e != nil {
+		return e.value, true
+	}
+	return v, false
+}
+
+// Range calls fn once for each entry in ascending key order.
+//
+// It stops whenever fn returns false. fn may modify the map.
+func (s *Snapshot[K, V]) Range(fn func(K, V) bool) {
+	s.merge(nil, fn)
+}
+
+// RangeFrom calls fn once for each entry with key greater than or equal to
+// pivot, in ascending key order.
+//
+// It stops whenever fn returns false. fn may modify the map.
+func (s *Snapshot[K, V]) RangeFrom(pivot K, fn func(K, V) bool) {
+	s.merge(&pivot, fn)
+}
+
+// Close releases the snapshot.
+func (s *Snapshot[K, V]) Close() {
+	if s.mu != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	if s.detach != nil {
+		s.detach(s)
+		s.detach = nil
+	}
+}
+
+// merge merges the map and the overlay in batches, fn is called without
+// holding the lock.
+func (s *Snapshot[K, V]) merge(pivot *K, fn func(K, V) bool) {
+	live := &syncBatch[K, V]{}
+	var keys []K
+	var values []V
+
+	after := false
+	for {
+		live.reset()
+		keys, values = keys[:0], values[:0]
+
+		s.rlock()
+		s.live.scan(pivot, after, live.add)
+		more := live.n == syncBatchSize
+
+		i := 0
+		s.overlay.scan(pivot, after, func(o *entry[K, saved[V]]) bool {
+			for ; i < live.n && s.less(live.keys[i], o.key); i++ {
+				keys = append(keys, live.keys[i])
+				values = append(values, live.values[i])
+			}
+			if more && i == live.n {
+				// Leave it to the next batch.
+				return false
+			}
+			if i < live.n && !s.less(o.key, live.keys[i]) {
+				// Changed after the snapshot.
+				i++
+			}
+			if o.value.present {
+				keys = append(keys, o.key)
+				values = append(values, o.value.value)
+			}
+			return true
+		})
+		for ; i < live.n; i++ {
+			keys = append(keys, live.keys[i])
+			values = append(values, live.values[i])
+		}
+		s.runlock()
+
+		for j := range keys {
+			if !fn(keys[j], values[j]) {
+				return
+			}
+		}
+		if !more {
+			return
+		}
+		last := live.keys[live.n-1]
+		pivot, after = &last, true
+	}
+}
+
+// Snapshot returns a read-only, point-in-time view of the map, see Snapshot.
+func (m *Map[K, V]) Snapshot() *Snapshot[K, V] {
+	s := &Snapshot[K, V]{
+		live:    m,
+		overlay: newKeyOverlay[K, V](),
+		less:    keyLess[K],
+		len:     m.Len(),
+		detach:  m.detach,
+	}
+	m.snaps = append(m.snaps, s)
+	return s
+}
+
+func (m *Map[K, V]) detach(s *Snapshot[K, V]) {
+	for i, s2 := range m.snaps {
+		if s2 == s {
+			m.snaps = append(m.snaps[:i], m.snaps[i+1:]...)
+			break
+		}
+	}
+	if len(m.snaps) == 0 {
+		m.snaps = nil
+	}
+}
+
+// record saves the state of k into the open snapshots before it is changed,
+// e is the current entry of k or nil.
+func (m *Map[K, V]) record(k K, e *entry[K, V]) {
+	for _, s := range m.snaps {
+		s.record(k, e)
+	}
+}
+
+func (m *Map[K, V]) recordAll() {
+	m.list.Iterate(func(e *entry[K, V]) bool {
+		m.record(e.key, e)
+		return true
+	})
+}
+
+func (m *Map[K, V]) find(k K) *entry[K, V] {
+	return m.list.Get(k)
+}
+
+func (m *Map[K, V]) scan(pivot *K, after bool, fn func(*entry[K, V]) bool) {
+	switch {
+	case pivot == nil:
+		m.list.Iterate(fn)
+	case after:
+		m.list.IterateAfter(*pivot, fn)
+	default:
+		m.list.IterateFrom(*pivot, fn)
+	}
+}
+
+// Snapshot returns a read-only, point-in-time view of the map, see Snapshot.
+func (m *OrderedMap[K, V]) Snapshot() *Snapshot[K, V] {
+	s := &Snapshot[K, V]{
+		live:    m,
+		overlay: newOrderedOverlay[K, V](),
+		less:    cmp.Less[K],
+		len:     m.Len(),
+		detach:  m.detach,
+	}
+	m.snaps = append(m.snaps, s)
+	return s
+}
+
+func (m *OrderedMap[K, V]) detach(s *Snapshot[K, V]) {
+	for i, s2 := range m.snaps {
+		if s2 == s {
+			m.snaps = append(m.snaps[:i], m.snaps[i+1:]...)
+			break
+		}
+	}
+	if len(m.snaps) == 0 {
+		m.snaps = nil
+	}
+}
+
+// record saves the state of k into the open snapshots before it is changed,
+// e is the current entry of k or nil.
+func (m *OrderedMap[K, V]) record(k K, e *entry[K, V]) {
+	for _, s := range m.snaps {
+		s.record(k, e)
+	}
+}
+
+func (m *OrderedMap[K, V]) recordAll() {
+	m.list.Iterate(func(e *entry[K, V]) bool {
+		m.record(e.key, e)
+		return true
+	})
+}
+
+func (m *OrderedMap[K, V]) find(k K) *entry[K, V] {
+	return m.list.Get(k)
+}
+
+func (m *OrderedMap[K, V]) scan(pivot *K, after bool, fn func(*entry[K, V]) bool) {
+	switch {
+	case pivot == nil:
+		m.list.Iterate(fn)
+	case after:
+		m.list.IterateAfter(*pivot, fn)
+	default:
+		m.list.IterateFrom(*pivot, fn)
+	}
+}
+
+// Snapshot returns a read-only, point-in-time view of the map, see Snapshot.
+// The snapshot is safe for concurrent use with the map.
+func (m *SyncMap[K, V]) Snapshot() *Snapshot[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.m.Snapshot()
+	s.mu = &m.mu
+	return s
+}
+
+// Snapshot returns a read-only, point-in-time view of the map, see Snapshot.
+// The snapshot is safe for concurrent use with the map.
+func (m *SyncOrderedMap[K, V]) Snapshot() *Snapshot[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.m.Snapshot()
+	s.mu = &m.mu
+	return s
+}
+
+// Snapshot returns a read-only, point-in-time view of the map, see Snapshot.
+func (m *FuncMap[K, V]) Snapshot() *Snapshot[K, V] {
+	s := &Snapshot[K, V]{
+		live:    m,
+		overlay: newFuncOverlay[K, V](m.list.Less()),
+		less:    m.list.Less(),
+		len:     m.Len(),
+		detach:  m.detach,
+	}
+	m.snaps = append(m.snaps, s)
+	return s
+}
+
+func (m *FuncMap[K, V]) detach(s *Snapshot[K, V]) {
+	for i, s2 := range m.snaps {
+		if s2 == s {
+			m.snaps = append(m.snaps[:i], m.snaps[i+1:]...)
+			break
+		}
+	}
+	if len(m.snaps) == 0 {
+		m.snaps = nil
+	}
+}
+
+// record saves the state of k into the open snapshots before it is changed,
+// e is the current entry of k or nil.
+func (m *FuncMap[K, V]) record(k K, e *entry[K, V]) {
+	for _, s := range m.snaps {
+		s.record(k, e)
+	}
+}
+
+func (m *FuncMap[K, V]) recordAll() {
+	m.list.Iterate(func(e *entry[K, V]) bool {
+		m.record(e.key, e)
+		return true
+	})
+}
+
+func (m *FuncMap[K, V]) find(k K) *entry[K, V] {
+	return m.list.Get(k)
+}
+
+func (m *FuncMap[K, V]) scan(pivot *K, after bool, fn func(*entry[K, V]) bool) {
+	switch {
+	case pivot == nil:
+		m.list.Iterate(fn)
+	case after:
+		m.list.IterateAfter(*pivot, fn)
+	default:
+		m.list.IterateFrom(*pivot, fn)
+	}
+}
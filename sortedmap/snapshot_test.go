@@ -0,0 +1,162 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/someonegg/gocontainer/sortedmap"
+)
+
+func snapshotKeys[K, V any](s *sortedmap.Snapshot[K, V]) (keys []K, values []V) {
+	s.Range(func(k K, v V) bool {
+		keys = append(keys, k)
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+func TestOrderedMapSnapshot(t *testing.T) {
+	m := sortedmap.NewOrdered[int, int]()
+	for k := 0; k < 200; k++ {
+		m.Set(k, k)
+	}
+
+	s := m.Snapshot()
+	defer s.Close()
+
+	m.Set(10, -10)
+	m.Set(500, 500)
+	m.Delete(20)
+	m.DeleteRange(100, 150, sortedmap.HalfOpen)
+	m.PopFirst()
+	m.PopLast()
+	m.Set(120, -120)
+
+	if s.Len() != 200 {
+		t.Fatalf("snapshot Len = %d, want 200", s.Len())
+	}
+	keys, values := snapshotKeys(s)
+	if len(keys) != 200 {
+		t.Fatalf("snapshot Range visited %d entries, want 200", len(keys))
+	}
+	for i := range keys {
+		if keys[i] != i || values[i] != i {
+			t.Fatalf("snapshot Range entry %d = (%d, %d)", i, keys[i], values[i])
+		}
+	}
+	for _, k := range []int{0, 10, 20, 120, 199} {
+		if v, ok := s.Get(k); !ok || v != k {
+			t.Fatalf("snapshot Get(%d) = (%d, %v), want (%d, true)", k, v, ok, k)
+		}
+	}
+	if _, ok := s.Get(500); ok {
+		t.Fatal("snapshot Get found a key inserted after it")
+	}
+
+	keys = keys[:0]
+	s.RangeFrom(98, func(k, v int) bool {
+		keys = append(keys, k)
+		return len(keys) < 4
+	})
+	if !reflect.DeepEqual(keys, []int{98, 99, 100, 101}) {
+		t.Fatalf("snapshot RangeFrom(98) = %v, want [98 99 100 101]", keys)
+	}
+
+	m.Clear()
+	if keys, _ := snapshotKeys(s); len(keys) != 200 {
+		t.Fatalf("snapshot Range after Clear visited %d entries, want 200", len(keys))
+	}
+
+	s2 := m.Snapshot()
+	m.Set(1, 1)
+	if keys, _ := snapshotKeys(s2); len(keys) != 0 {
+		t.Fatalf("snapshot of empty map visited %v", keys)
+	}
+	s2.Close()
+}
+
+func TestMapSnapshot(t *testing.T) {
+	m := sortedmap.New[testKey, string]()
+	m.Set(testKey{1, 0}, "a")
+	m.Set(testKey{2, 0}, "b")
+
+	s := m.Snapshot()
+	m.Set(testKey{1, 5}, "c")
+	m.Set(testKey{2, 0}, "B")
+	m.Delete(testKey{1, 0})
+	s.Close()
+	m.Set(testKey{3, 0}, "d")
+
+	s = m.Snapshot()
+	defer s.Close()
+	m.Delete(testKey{3, 0})
+	m.Swap(testKey{2, 0}, "e")
+	m.GetOrInsert(testKey{4, 0}, func() string { return "f" })
+	m.Update(testKey{1, 5}, func(*string, bool) (string, bool) { return "", false })
+
+	keys, values := snapshotKeys(s)
+	if !reflect.DeepEqual(keys, []testKey{{1, 5}, {2, 0}, {3, 0}}) {
+		t.Fatalf("snapshot keys = %v", keys)
+	}
+	if !reflect.DeepEqual(values, []string{"c", "B", "d"}) {
+		t.Fatalf("snapshot values = %v", values)
+	}
+}
+
+func TestSyncOrderedMapSnapshot(t *testing.T) {
+	m := sortedmap.NewSyncOrdered[int, int]()
+	const n = 1000
+	for k := 0; k < n; k++ {
+		m.Set(k, k)
+	}
+
+	s := m.Snapshot()
+	defer s.Close()
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				k := r.Intn(2 * n)
+				if r.Intn(2) == 0 {
+					m.Set(k, -k)
+				} else {
+					m.Delete(k)
+				}
+			}
+		}(int64(w))
+	}
+
+	for i := 0; i < 20; i++ {
+		next := 0
+		s.Range(func(k, v int) bool {
+			if k != next || v != k {
+				t.Errorf("snapshot Range entry (%d, %d), want (%d, %d)", k, v, next, next)
+			}
+			next++
+			return true
+		})
+		if next != n {
+			t.Errorf("snapshot Range visited %d entries, want %d", next, n)
+		}
+	}
+
+	close(stop)
+	wg.Wait()
+}
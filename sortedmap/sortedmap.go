@@ -21,12 +21,17 @@ func (e *entry[K, V]) Key() K {
 	return e.key
 }
 
+func keyLess[K cmp.Key[K]](a, b K) bool {
+	return a.Less(b)
+}
+
 // Map is a sorted map whose keys define their ordering with Less.
 //
 // The address of a value is stable until the entry is deleted or the map is
 // cleared. Keys are not addressable through this API.
 type Map[K cmp.Key[K], V any] struct {
-	list uskiplist.List[K, entry[K, V], *entry[K, V]]
+	list  uskiplist.List[K, entry[K, V], *entry[K, V]]
+	snaps []*Snapshot[K, V]
 }
 
 // New creates and initializes a new sorted map.
@@ -36,11 +41,33 @@ func New[K cmp.Key[K], V any]() *Map[K, V] {
 	return m
 }
 
-// Init initializes the map.
+// NewRanked creates and initializes a new ranked sorted map.
+//
+// A ranked map also keeps the position of entries, so At, IndexOf and
+// RangeIndex take O(log n) instead of O(n), at the cost of slightly more
+// memory and slower updates.
+func NewRanked[K cmp.Key[K], V any]() *Map[K, V] {
+	m := &Map[K, V]{}
+	m.InitRanked()
+	return m
+}
+
+// Init initializes the map, a ranked map stays ranked.
 func (m *Map[K, V]) Init() {
+	if m.snaps != nil {
+		m.recordAll()
+	}
 	m.list.Init()
 }
 
+// InitRanked initializes the map as a ranked map, see NewRanked.
+func (m *Map[K, V]) InitRanked() {
+	if m.snaps != nil {
+		m.recordAll()
+	}
+	m.list.InitWith(uskiplist.Options{Ranked: true})
+}
+
 // Len returns number of entries in the map.
 func (m *Map[K, V]) Len() int {
 	return m.list.Len()
@@ -68,30 +95,95 @@ func (m *Map[K, V]) Get(k K) *V {
 // When k already exists, Set overwrites the existing value without changing its
 // address.
 func (m *Map[K, V]) Set(k K, v V) *V {
-	e := m.list.Get(k)
-	if e != nil {
-		e.value = v
-		return &e.value
-	}
+	e := m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if m.snaps != nil {
+			m.record(k, e)
+		}
+		if e != nil {
+			e.value = v
+			return e
+		}
+		return &entry[K, V]{key: k, value: v}
+	})
+	return &e.value
+}
+
+// Swap sets the value for k and returns the previous value, ok tells whether
+// k existed.
+func (m *Map[K, V]) Swap(k K, v V) (old V, ok bool) {
+	m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if m.snaps != nil {
+			m.record(k, e)
+		}
+		if e != nil {
+			old, ok = e.value, true
+			e.value = v
+			return e
+		}
+		return &entry[K, V]{key: k, value: v}
+	})
+	return
+}
+
+// GetOrInsert returns a pointer to the value associated with k. When k is not
+// found, it inserts the value returned by fn, and inserted is true.
+func (m *Map[K, V]) GetOrInsert(k K, fn func() V) (v *V, inserted bool) {
+	e := m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if e != nil {
+			return e
+		}
+		if m.snaps != nil {
+			m.record(k, nil)
+		}
+		inserted = true
+		return &entry[K, V]{key: k, value: fn()}
+	})
+	return &e.value, inserted
+}
+
+// Update calls fn with the value associated with k, old is nil when k is not
+// found. The value returned by fn is stored when keep is true, otherwise k is
+// removed. It returns a pointer to the stored value, or nil.
+//
+// An existing value keeps its address. fn must not modify the map.
+func (m *Map[K, V]) Update(k K, fn func(old *V, exists bool) (v V, keep bool)) *V {
+	e := m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if m.snaps != nil {
+			m.record(k, e)
+		}
+		if e == nil {
+			v, keep := fn(nil, false)
+			if !keep {
+				return nil
+			}
+			return &entry[K, V]{key: k, value: v}
+		}
 
-	e = &entry[K, V]{
-		key:   k,
-		value: v,
+		v, keep := fn(&e.value, true)
+		if !keep {
+			return nil
+		}
+		e.value = v
+		return e
+	})
+	if e == nil {
+		return nil
 	}
-	m.list.Insert(e)
 	return &e.value
 }
 
 // Delete removes k from the map and returns the removed value.
 func (m *Map[K, V]) Delete(k K) (old V, ok bool) {
-	e := m.list.Get(k)
-	if e == nil {
-		return old, false
-	}
-
-	old = e.value
-	m.list.Delete(k)
-	return old, true
+	m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if e != nil {
+			if m.snaps != nil {
+				m.record(k, e)
+			}
+			old, ok = e.value, true
+		}
+		return nil
+	})
+	return
 }
 
 // Range calls fn once for each entry in ascending key order.
@@ -115,12 +207,34 @@ func (m *Map[K, V]) RangeFrom(pivot K, fn func(K, *V) bool) {
 	})
 }
 
+// RangeReverse calls fn once for each entry in descending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *Map[K, V]) RangeReverse(fn func(K, *V) bool) {
+	m.list.IterateReverse(func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// RangeBefore calls fn once for each entry with key less than pivot, in
+// descending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *Map[K, V]) RangeBefore(pivot K, fn func(K, *V) bool) {
+	m.list.IterateBefore(pivot, func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
 // OrderedMap is a sorted map whose keys are ordered with the < operator.
 //
 // The address of a value is stable until the entry is deleted or the map is
 // cleared. Keys are not addressable through this API.
 type OrderedMap[K cmp.Ordered, V any] struct {
-	list uskiplist.ListO[K, entry[K, V], *entry[K, V]]
+	list  uskiplist.ListO[K, entry[K, V], *entry[K, V]]
+	snaps []*Snapshot[K, V]
 }
 
 // NewOrdered creates and initializes a new sorted map for ordered keys.
@@ -130,11 +244,34 @@ func NewOrdered[K cmp.Ordered, V any]() *OrderedMap[K, V] {
 	return m
 }
 
-// Init initializes the map.
+// NewOrderedRanked creates and initializes a new ranked sorted map for
+// ordered keys.
+//
+// A ranked map also keeps the position of entries, so At, IndexOf and
+// RangeIndex take O(log n) instead of O(n), at the cost of slightly more
+// memory and slower updates.
+func NewOrderedRanked[K cmp.Ordered, V any]() *OrderedMap[K, V] {
+	m := &OrderedMap[K, V]{}
+	m.InitRanked()
+	return m
+}
+
+// Init initializes the map, a ranked map stays ranked.
 func (m *OrderedMap[K, V]) Init() {
+	if m.snaps != nil {
+		m.recordAll()
+	}
 	m.list.Init()
 }
 
+// InitRanked initializes the map as a ranked map, see NewRanked.
+func (m *OrderedMap[K, V]) InitRanked() {
+	if m.snaps != nil {
+		m.recordAll()
+	}
+	m.list.InitWith(uskiplist.Options{Ranked: true})
+}
+
 // Len returns number of entries in the map.
 func (m *OrderedMap[K, V]) Len() int {
 	return m.list.Len()
@@ -162,37 +299,311 @@ func (m *OrderedMap[K, V]) Get(k K) *V {
 // When k already exists, Set overwrites the existing value without changing its
 // address.
 func (m *OrderedMap[K, V]) Set(k K, v V) *V {
-	e := m.list.Get(k)
-	if e != nil {
-		e.value = v
-		return &e.value
-	}
+	e := m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if m.snaps != nil {
+			m.record(k, e)
+		}
+		if e != nil {
+			e.value = v
+			return e
+		}
+		return &entry[K, V]{key: k, value: v}
+	})
+	return &e.value
+}
+
+// Swap sets the value for k and returns the previous value, ok tells whether
+// k existed.
+func (m *OrderedMap[K, V]) Swap(k K, v V) (old V, ok bool) {
+	m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if m.snaps != nil {
+			m.record(k, e)
+		}
+		if e != nil {
+			old, ok = e.value, true
+			e.value = v
+			return e
+		}
+		return &entry[K, V]{key: k, value: v}
+	})
+	return
+}
+
+// GetOrInsert returns a pointer to the value associated with k. When k is not
+// found, it inserts the value returned by fn, and inserted is true.
+func (m *OrderedMap[K, V]) GetOrInsert(k K, fn func() V) (v *V, inserted bool) {
+	e := m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if e != nil {
+			return e
+		}
+		if m.snaps != nil {
+			m.record(k, nil)
+		}
+		inserted = true
+		return &entry[K, V]{key: k, value: fn()}
+	})
+	return &e.value, inserted
+}
+
+// Update calls fn with the value associated with k, old is nil when k is not
+// found. The value returned by fn is stored when keep is true, otherwise k is
+// removed. It returns a pointer to the stored value, or nil.
+//
+// An existing value keeps its address. fn must not modify the map.
+func (m *OrderedMap[K, V]) Update(k K, fn func(old *V, exists bool) (v V, keep bool)) *V {
+	e := m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if m.snaps != nil {
+			m.record(k, e)
+		}
+		if e == nil {
+			v, keep := fn(nil, false)
+			if !keep {
+				return nil
+			}
+			return &entry[K, V]{key: k, value: v}
+		}
 
-	e = &entry[K, V]{
-		key:   k,
-		value: v,
+		v, keep := fn(&e.value, true)
+		if !keep {
+			return nil
+		}
+		e.value = v
+		return e
+	})
+	if e == nil {
+		return nil
 	}
-	m.list.Insert(e)
 	return &e.value
 }
 
 // Delete removes k from the map and returns the removed value.
 func (m *OrderedMap[K, V]) Delete(k K) (old V, ok bool) {
+	m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if e != nil {
+			if m.snaps != nil {
+				m.record(k, e)
+			}
+			old, ok = e.value, true
+		}
+		return nil
+	})
+	return
+}
+
+// Range calls fn once for each entry in ascending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *OrderedMap[K, V]) Range(fn func(K, *V) bool) {
+	m.list.Iterate(func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// RangeFrom calls fn once for each entry with key greater than or equal to
+// pivot, in ascending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *OrderedMap[K, V]) RangeFrom(pivot K, fn func(K, *V) bool) {
+	m.list.IterateFrom(pivot, func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// RangeReverse calls fn once for each entry in descending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *OrderedMap[K, V]) RangeReverse(fn func(K, *V) bool) {
+	m.list.IterateReverse(func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// RangeBefore calls fn once for each entry with key less than pivot, in
+// descending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *OrderedMap[K, V]) RangeBefore(pivot K, fn func(K, *V) bool) {
+	m.list.IterateBefore(pivot, func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// FuncMap is a sorted map whose keys are ordered by a less function supplied
+// at construction time, less must be a strict weak ordering. It sorts keys
+// without a Less method, or in another order, such as descending or case
+// insensitive.
+//
+// The address of a value is stable until the entry is deleted or the map is
+// cleared. Keys are not addressable through this API. Unlike Map and
+// OrderedMap, a zero FuncMap can not be made usable with Init since it has no
+// less function, decoding into it returns ErrNoLess.
+type FuncMap[K, V any] struct {
+	list  uskiplist.ListF[K, entry[K, V], *entry[K, V]]
+	snaps []*Snapshot[K, V]
+}
+
+// NewFunc creates and initializes a new sorted map ordering keys with less.
+func NewFunc[K, V any](less func(a, b K) bool) *FuncMap[K, V] {
+	m := &FuncMap[K, V]{}
+	m.list.InitFunc(less, uskiplist.Options{})
+	return m
+}
+
+// NewFuncRanked creates and initializes a new ranked sorted map ordering keys
+// with less.
+//
+// A ranked map also keeps the position of entries, so At, IndexOf and
+// RangeIndex take O(log n) instead of O(n), at the cost of slightly more
+// memory and slower updates.
+func NewFuncRanked[K, V any](less func(a, b K) bool) *FuncMap[K, V] {
+	m := &FuncMap[K, V]{}
+	m.list.InitFunc(less, uskiplist.Options{Ranked: true})
+	return m
+}
+
+// Init initializes the map, a ranked map stays ranked.
+func (m *FuncMap[K, V]) Init() {
+	if m.snaps != nil {
+		m.recordAll()
+	}
+	m.list.Init()
+}
+
+// InitRanked initializes the map as a ranked map, see NewFuncRanked.
+func (m *FuncMap[K, V]) InitRanked() {
+	if m.snaps != nil {
+		m.recordAll()
+	}
+	m.list.InitWith(uskiplist.Options{Ranked: true})
+}
+
+// Len returns number of entries in the map.
+func (m *FuncMap[K, V]) Len() int {
+	return m.list.Len()
+}
+
+// Clear removes all entries from the map.
+func (m *FuncMap[K, V]) Clear() {
+	m.Init()
+}
+
+// Get returns a pointer to the value associated with k.
+//
+// It returns nil when k is not found. The returned pointer remains valid until
+// the entry is deleted or the map is cleared.
+func (m *FuncMap[K, V]) Get(k K) *V {
 	e := m.list.Get(k)
 	if e == nil {
-		return old, false
+		return nil
 	}
+	return &e.value
+}
+
+// Set sets the value for k and returns a pointer to the stored value.
+//
+// When k already exists, Set overwrites the existing value without changing its
+// address.
+func (m *FuncMap[K, V]) Set(k K, v V) *V {
+	e := m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if m.snaps != nil {
+			m.record(k, e)
+		}
+		if e != nil {
+			e.value = v
+			return e
+		}
+		return &entry[K, V]{key: k, value: v}
+	})
+	return &e.value
+}
 
-	old = e.value
-	m.list.Delete(k)
-	return old, true
+// Swap sets the value for k and returns the previous value, ok tells whether
+// k existed.
+func (m *FuncMap[K, V]) Swap(k K, v V) (old V, ok bool) {
+	m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if m.snaps != nil {
+			m.record(k, e)
+		}
+		if e != nil {
+			old, ok = e.value, true
+			e.value = v
+			return e
+		}
+		return &entry[K, V]{key: k, value: v}
+	})
+	return
+}
+
+// GetOrInsert returns a pointer to the value associated with k. When k is not
+// found, it inserts the value returned by fn, and inserted is true.
+func (m *FuncMap[K, V]) GetOrInsert(k K, fn func() V) (v *V, inserted bool) {
+	e := m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if e != nil {
+			return e
+		}
+		if m.snaps != nil {
+			m.record(k, nil)
+		}
+		inserted = true
+		return &entry[K, V]{key: k, value: fn()}
+	})
+	return &e.value, inserted
+}
+
+// Update calls fn with the value associated with k, old is nil when k is not
+// found. The value returned by fn is stored when keep is true, otherwise k is
+// removed. It returns a pointer to the stored value, or nil.
+//
+// An existing value keeps its address. fn must not modify the map.
+func (m *FuncMap[K, V]) Update(k K, fn func(old *V, exists bool) (v V, keep bool)) *V {
+	e := m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if m.snaps != nil {
+			m.record(k, e)
+		}
+		if e == nil {
+			v, keep := fn(nil, false)
+			if !keep {
+				return nil
+			}
+			return &entry[K, V]{key: k, value: v}
+		}
+
+		v, keep := fn(&e.value, true)
+		if !keep {
+			return nil
+		}
+		e.value = v
+		return e
+	})
+	if e == nil {
+		return nil
+	}
+	return &e.value
+}
+
+// Delete removes k from the map and returns the removed value.
+func (m *FuncMap[K, V]) Delete(k K) (old V, ok bool) {
+	m.list.Compute(k, func(e *entry[K, V]) *entry[K, V] {
+		if e != nil {
+			if m.snaps != nil {
+				m.record(k, e)
+			}
+			old, ok = e.value, true
+		}
+		return nil
+	})
+	return
 }
 
 // Range calls fn once for each entry in ascending key order.
 //
 // It stops whenever fn returns false. The current entry may be deleted during
 // iteration, and values may be changed through the provided pointer.
-func (m *OrderedMap[K, V]) Range(fn func(K, *V) bool) {
+func (m *FuncMap[K, V]) Range(fn func(K, *V) bool) {
 	m.list.Iterate(func(e *entry[K, V]) bool {
 		return fn(e.key, &e.value)
 	})
@@ -203,8 +614,29 @@ func (m *OrderedMap[K, V]) Range(fn func(K, *V) bool) {
 //
 // It stops whenever fn returns false. The current entry may be deleted during
 // iteration, and values may be changed through the provided pointer.
-func (m *OrderedMap[K, V]) RangeFrom(pivot K, fn func(K, *V) bool) {
+func (m *FuncMap[K, V]) RangeFrom(pivot K, fn func(K, *V) bool) {
 	m.list.IterateFrom(pivot, func(e *entry[K, V]) bool {
 		return fn(e.key, &e.value)
 	})
 }
+
+// RangeReverse calls fn once for each entry in descending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *FuncMap[K, V]) RangeReverse(fn func(K, *V) bool) {
+	m.list.IterateReverse(func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
+
+// RangeBefore calls fn once for each entry with key less than pivot, in
+// descending key order.
+//
+// It stops whenever fn returns false. The current entry may be deleted during
+// iteration, and values may be changed through the provided pointer.
+func (m *FuncMap[K, V]) RangeBefore(pivot K, fn func(K, *V) bool) {
+	m.list.IterateBefore(pivot, func(e *entry[K, V]) bool {
+		return fn(e.key, &e.value)
+	})
+}
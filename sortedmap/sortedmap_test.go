@@ -5,6 +5,7 @@
 package sortedmap_test
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -203,3 +204,600 @@ func TestMapDeleteCurrentDuringRangeFrom(t *testing.T) {
 		t.Fatalf("Get d = %q, want d", got)
 	}
 }
+
+func TestOrderedMapRangeReverse(t *testing.T) {
+	m := sortedmap.NewOrdered[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Set(i, i*10)
+	}
+
+	var keys []int
+	m.RangeReverse(func(k int, v *int) bool {
+		if *v != k*10 {
+			t.Fatalf("RangeReverse value of %d = %d, want %d", k, *v, k*10)
+		}
+		keys = append(keys, k)
+		if k%3 == 0 {
+			m.Delete(k)
+		}
+		return len(keys) < 500
+	})
+	if len(keys) != 500 || keys[0] != 999 || keys[499] != 500 {
+		t.Fatalf("RangeReverse keys = %v..., want 999..500", keys[:3])
+	}
+	for i, k := range keys {
+		if k != 999-i {
+			t.Fatalf("RangeReverse keys[%d] = %d, want %d", i, k, 999-i)
+		}
+	}
+
+	keys = keys[:0]
+	m.RangeBefore(7, func(k int, _ *int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if !reflect.DeepEqual(keys, []int{6, 5, 4, 3, 2, 1, 0}) {
+		t.Fatalf("RangeBefore keys = %v, want [6 5 4 3 2 1 0]", keys)
+	}
+	if m.Len() != 1000-167 {
+		t.Fatalf("Len = %d, want %d", m.Len(), 1000-167)
+	}
+}
+
+func TestMapRangeBefore(t *testing.T) {
+	m := sortedmap.New[testKey, string]()
+	m.Set(testKey{major: 1, minor: 1}, "a")
+	m.Set(testKey{major: 1, minor: 2}, "b")
+	m.Set(testKey{major: 2, minor: 1}, "c")
+
+	var values []string
+	m.RangeBefore(testKey{major: 2, minor: 0}, func(_ testKey, v *string) bool {
+		values = append(values, *v)
+		return true
+	})
+	if !reflect.DeepEqual(values, []string{"b", "a"}) {
+		t.Fatalf("RangeBefore values = %v, want [b a]", values)
+	}
+
+	values = values[:0]
+	m.RangeReverse(func(_ testKey, v *string) bool {
+		values = append(values, *v)
+		return true
+	})
+	if !reflect.DeepEqual(values, []string{"c", "b", "a"}) {
+		t.Fatalf("RangeReverse values = %v, want [c b a]", values)
+	}
+}
+
+func TestOrderedMapRank(t *testing.T) {
+	for _, m := range []*sortedmap.OrderedMap[int, int]{
+		sortedmap.NewOrderedRanked[int, int](),
+		sortedmap.NewOrdered[int, int](),
+	} {
+		for i := 0; i < 1000; i++ {
+			m.Set(i*2, i)
+		}
+		for i := 0; i < 1000; i += 4 {
+			m.Delete(i * 2)
+		}
+
+		k, v, ok := m.At(0)
+		if !ok || k != 2 || *v != 1 {
+			t.Fatalf("At(0) = (%d, %v, %v), want (2, 1, true)", k, *v, ok)
+		}
+		k, v, ok = m.At(m.Len() - 1)
+		if !ok || k != 1998 || *v != 999 {
+			t.Fatalf("At(Len-1) = (%d, %v, %v), want (1998, 999, true)", k, *v, ok)
+		}
+		if _, v, ok := m.At(m.Len()); ok || v != nil {
+			t.Fatalf("At(Len) = (%v, %v), want (nil, false)", v, ok)
+		}
+
+		if got := m.IndexOf(6); got != 2 {
+			t.Fatalf("IndexOf(6) = %d, want 2", got)
+		}
+		if got := m.IndexOf(8); got != -1 {
+			t.Fatalf("IndexOf(8) = %d, want -1", got)
+		}
+
+		var keys []int
+		m.RangeIndex(3, 6, func(k int, _ *int) bool {
+			keys = append(keys, k)
+			return true
+		})
+		if !reflect.DeepEqual(keys, []int{10, 12, 14}) {
+			t.Fatalf("RangeIndex(3, 6) keys = %v, want [10 12 14]", keys)
+		}
+
+		m.Clear()
+		m.Set(1, 1)
+		if got := m.IndexOf(1); got != 0 {
+			t.Fatalf("IndexOf after Clear = %d, want 0", got)
+		}
+	}
+}
+
+func TestOrderedMapNeighbors(t *testing.T) {
+	m := sortedmap.NewOrdered[int, string]()
+
+	if _, _, ok := m.First(); ok {
+		t.Fatal("First of empty map returned ok")
+	}
+	if _, _, ok := m.PopLast(); ok {
+		t.Fatal("PopLast of empty map returned ok")
+	}
+
+	m.Set(10, "a")
+	m.Set(20, "b")
+	m.Set(30, "c")
+
+	type result struct {
+		k  int
+		v  string
+		ok bool
+	}
+	get := func(k int, v *string, ok bool) result {
+		if !ok {
+			return result{}
+		}
+		return result{k, *v, ok}
+	}
+
+	tests := []struct {
+		name string
+		got  result
+		want result
+	}{
+		{"Floor(20)", get(m.Floor(20)), result{20, "b", true}},
+		{"Floor(25)", get(m.Floor(25)), result{20, "b", true}},
+		{"Floor(5)", get(m.Floor(5)), result{}},
+		{"Ceiling(20)", get(m.Ceiling(20)), result{20, "b", true}},
+		{"Ceiling(15)", get(m.Ceiling(15)), result{20, "b", true}},
+		{"Ceiling(35)", get(m.Ceiling(35)), result{}},
+		{"Lower(20)", get(m.Lower(20)), result{10, "a", true}},
+		{"Lower(10)", get(m.Lower(10)), result{}},
+		{"Higher(20)", get(m.Higher(20)), result{30, "c", true}},
+		{"Higher(30)", get(m.Higher(30)), result{}},
+		{"First()", get(m.First()), result{10, "a", true}},
+		{"Last()", get(m.Last()), result{30, "c", true}},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Fatalf("%s = %+v, want %+v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	k, v, ok := m.PopFirst()
+	if !ok || k != 10 || v != "a" {
+		t.Fatalf("PopFirst = (%d, %q, %v), want (10, a, true)", k, v, ok)
+	}
+	k, v, ok = m.PopLast()
+	if !ok || k != 30 || v != "c" {
+		t.Fatalf("PopLast = (%d, %q, %v), want (30, c, true)", k, v, ok)
+	}
+	if m.Len() != 1 || m.Get(20) == nil {
+		t.Fatalf("map after pops has %d entries, want only 20", m.Len())
+	}
+}
+
+func TestMapNeighbors(t *testing.T) {
+	m := sortedmap.New[testKey, string]()
+	m.Set(testKey{major: 1, minor: 1}, "a")
+	m.Set(testKey{major: 2, minor: 1}, "b")
+
+	if k, v, ok := m.Floor(testKey{major: 1, minor: 5}); !ok || *v != "a" || k.major != 1 {
+		t.Fatalf("Floor = (%+v, %v, %v), want a", k, v, ok)
+	}
+	if k, v, ok := m.Higher(testKey{major: 1, minor: 1}); !ok || *v != "b" || k.major != 2 {
+		t.Fatalf("Higher = (%+v, %v, %v), want b", k, v, ok)
+	}
+	if _, v, ok := m.PopLast(); !ok || v != "b" {
+		t.Fatalf("PopLast = (%q, %v), want b", v, ok)
+	}
+}
+
+func TestOrderedMapRangeBetween(t *testing.T) {
+	m := sortedmap.NewOrdered[int, int]()
+	for i := 1; i <= 10; i++ {
+		m.Set(i, i)
+	}
+
+	tests := []struct {
+		bounds sortedmap.Bounds
+		want   []int
+	}{
+		{sortedmap.Open, []int{4, 5, 6}},
+		{sortedmap.HalfOpen, []int{3, 4, 5, 6}},
+		{sortedmap.IncludeHi, []int{4, 5, 6, 7}},
+		{sortedmap.Closed, []int{3, 4, 5, 6, 7}},
+	}
+	for _, tt := range tests {
+		var keys []int
+		m.RangeBetween(3, 7, tt.bounds, func(k int, _ *int) bool {
+			keys = append(keys, k)
+			return true
+		})
+		if !reflect.DeepEqual(keys, tt.want) {
+			t.Fatalf("RangeBetween(3, 7, %d) = %v, want %v", tt.bounds, keys, tt.want)
+		}
+	}
+
+	if n := m.DeleteRange(3, 7, sortedmap.HalfOpen); n != 4 {
+		t.Fatalf("DeleteRange = %d, want 4", n)
+	}
+	if n := m.DeleteRange(20, 30, sortedmap.Closed); n != 0 {
+		t.Fatalf("DeleteRange outside = %d, want 0", n)
+	}
+
+	var keys []int
+	m.Range(func(k int, _ *int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if !reflect.DeepEqual(keys, []int{1, 2, 7, 8, 9, 10}) {
+		t.Fatalf("keys after DeleteRange = %v, want [1 2 7 8 9 10]", keys)
+	}
+}
+
+func TestMapDeleteRange(t *testing.T) {
+	m := sortedmap.New[testKey, string]()
+	m.Set(testKey{major: 1, minor: 1}, "a")
+	m.Set(testKey{major: 1, minor: 2}, "b")
+	m.Set(testKey{major: 2, minor: 1}, "c")
+
+	n := m.DeleteRange(testKey{major: 1}, testKey{major: 2}, sortedmap.Open)
+	if n != 2 || m.Len() != 1 || m.Get(testKey{major: 2, minor: 1}) == nil {
+		t.Fatalf("DeleteRange = %d, Len = %d, want 2 and 1", n, m.Len())
+	}
+}
+
+func TestOrderedMultiMap(t *testing.T) {
+	m := sortedmap.NewOrderedMulti[int, string]()
+	m.Add(2, "b1")
+	m.Add(1, "a1")
+	m.Add(2, "b2")
+	m.Add(3, "c1")
+	m.Add(2, "b3")
+
+	if m.Len() != 5 || m.Count(2) != 3 || m.Count(4) != 0 {
+		t.Fatalf("Len = %d, Count(2) = %d, want 5 and 3", m.Len(), m.Count(2))
+	}
+	if got := m.Get(2); got == nil || *got != "b1" {
+		t.Fatalf("Get(2) = %v, want b1", got)
+	}
+
+	var values []string
+	for _, v := range m.GetAll(2) {
+		values = append(values, *v)
+	}
+	if !reflect.DeepEqual(values, []string{"b1", "b2", "b3"}) {
+		t.Fatalf("GetAll(2) = %v, want [b1 b2 b3]", values)
+	}
+
+	values = values[:0]
+	m.RangeReverse(func(_ int, v *string) bool {
+		values = append(values, *v)
+		return true
+	})
+	if !reflect.DeepEqual(values, []string{"c1", "b3", "b2", "b1", "a1"}) {
+		t.Fatalf("RangeReverse = %v, want [c1 b3 b2 b1 a1]", values)
+	}
+
+	if old, ok := m.DeleteOne(2); !ok || old != "b1" {
+		t.Fatalf("DeleteOne(2) = (%q, %v), want (b1, true)", old, ok)
+	}
+	if n := m.DeleteAll(2); n != 2 {
+		t.Fatalf("DeleteAll(2) = %d, want 2", n)
+	}
+	if _, ok := m.DeleteOne(2); ok {
+		t.Fatal("DeleteOne of missing key returned ok")
+	}
+
+	values = values[:0]
+	m.Range(func(_ int, v *string) bool {
+		values = append(values, *v)
+		return true
+	})
+	if !reflect.DeepEqual(values, []string{"a1", "c1"}) {
+		t.Fatalf("Range = %v, want [a1 c1]", values)
+	}
+}
+
+func TestMultiMap(t *testing.T) {
+	m := sortedmap.NewMulti[testKey, int]()
+	k := testKey{major: 1}
+	m.Add(k, 1)
+	m.Add(k, 2)
+	m.Add(testKey{major: 0}, 0)
+
+	var values []int
+	m.RangeFrom(k, func(_ testKey, v *int) bool {
+		values = append(values, *v)
+		return true
+	})
+	if !reflect.DeepEqual(values, []int{1, 2}) {
+		t.Fatalf("RangeFrom = %v, want [1 2]", values)
+	}
+	if m.Count(k) != 2 {
+		t.Fatalf("Count = %d, want 2", m.Count(k))
+	}
+}
+
+func TestOrderedMapBuildFromSorted(t *testing.T) {
+	m := sortedmap.NewOrderedRanked[int, string]()
+	m.Set(100, "old")
+	s := m.Snapshot()
+	defer s.Close()
+
+	n := m.BuildFromSorted([]int{1, 3, 2, 5}, []string{"a", "c", "b", "e"}, sortedmap.BuildOptions{})
+	if n != 3 || m.Len() != 3 {
+		t.Fatalf("BuildFromSorted = %d, Len = %d, want 3", n, m.Len())
+	}
+
+	b := sortedmap.NewOrderedBuilder(m, sortedmap.BuildOptions{Deterministic: true})
+	if !b.Append(7, "g") || b.Append(6, "f") {
+		t.Fatal("Append did not check the order")
+	}
+	m.Set(4, "d")
+
+	var keys []int
+	m.Range(func(k int, v *string) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if !reflect.DeepEqual(keys, []int{1, 3, 4, 5, 7}) {
+		t.Fatalf("keys = %v, want [1 3 4 5 7]", keys)
+	}
+	if k, _, _ := m.At(3); k != 5 {
+		t.Fatalf("At(3) = %d, want 5", k)
+	}
+
+	if v, ok := s.Get(100); !ok || v != "old" || s.Len() != 1 {
+		t.Fatalf("snapshot Get(100) = (%q, %v)", v, ok)
+	}
+	if _, ok := s.Get(7); ok {
+		t.Fatal("snapshot Get found an appended key")
+	}
+}
+
+func TestFuncMap(t *testing.T) {
+	desc := func(a, b int) bool { return a > b }
+	m := sortedmap.NewFuncRanked[int, string](desc)
+	for _, k := range []int{3, 1, 4, 5, 9, 2, 6} {
+		m.Set(k, string(rune('a'+k)))
+	}
+
+	var keys []int
+	m.Range(func(k int, v *string) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if !reflect.DeepEqual(keys, []int{9, 6, 5, 4, 3, 2, 1}) {
+		t.Fatalf("Range = %v, want descending", keys)
+	}
+	if k, _, _ := m.First(); k != 9 {
+		t.Fatalf("First = %d, want 9", k)
+	}
+	if k, _, _ := m.Ceiling(8); k != 6 {
+		t.Fatalf("Ceiling(8) = %d, want 6", k)
+	}
+	if i := m.IndexOf(4); i != 3 {
+		t.Fatalf("IndexOf(4) = %d, want 3", i)
+	}
+
+	s := m.Snapshot()
+	defer s.Close()
+	if n := m.DeleteRange(6, 3, sortedmap.Closed); n != 4 {
+		t.Fatalf("DeleteRange = %d, want 4", n)
+	}
+	m.Clear()
+	m.Set(7, "h")
+	if v, ok := s.Get(5); !ok || v != "f" || s.Len() != 7 {
+		t.Fatalf("snapshot Get(5) = (%q, %v)", v, ok)
+	}
+	if _, ok := s.Get(7); ok {
+		t.Fatal("snapshot observed a later Set")
+	}
+
+	other := sortedmap.NewFunc[int, string](desc)
+	other.BuildFromSorted([]int{8, 7, 1}, []string{"x", "y", "z"}, sortedmap.BuildOptions{Strict: true})
+	u := m.Union(other, nil)
+	keys = keys[:0]
+	u.Range(func(k int, v *string) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if !reflect.DeepEqual(keys, []int{8, 7, 1}) || *u.Get(7) != "y" {
+		t.Fatalf("Union = %v", keys)
+	}
+}
+
+func TestOrderedMapCursor(t *testing.T) {
+	m := sortedmap.NewOrdered[int, string]()
+	for _, k := range []int{5, 1, 3, 9, 7} {
+		m.Set(k, "v")
+	}
+
+	c := m.Cursor()
+	var keys []int
+	for ; c.Valid(); c.Next() {
+		keys = append(keys, c.Key())
+		if c.Key() == 3 {
+			m.Delete(3)
+			m.Set(4, "new")
+			*c.Value() = "deleted"
+		}
+	}
+	if !reflect.DeepEqual(keys, []int{1, 3, 4, 5, 7, 9}) {
+		t.Fatalf("Cursor keys = %v", keys)
+	}
+
+	c.Seek(6)
+	if !c.Valid() || c.Key() != 7 || *c.Value() != "v" {
+		t.Fatalf("Seek(6) = %d", c.Key())
+	}
+	c.SeekFirst()
+	if c.Key() != 1 {
+		t.Fatalf("SeekFirst = %d, want 1", c.Key())
+	}
+
+	f := sortedmap.NewFunc[int, string](func(a, b int) bool { return a > b })
+	f.Set(1, "a")
+	f.Set(2, "b")
+	fc := f.Cursor()
+	if fc.Key() != 2 {
+		t.Fatalf("FuncMap cursor at %d, want 2", fc.Key())
+	}
+	fc.Next()
+	fc.Next()
+	if fc.Valid() {
+		t.Fatal("FuncMap cursor is valid after the last entry")
+	}
+}
+
+func TestOrderedMapPage(t *testing.T) {
+	m := sortedmap.NewOrdered[int, int]()
+	for k := 1; k <= 10; k++ {
+		m.Set(k*10, k)
+	}
+
+	var keys []int
+	entries, next, more := m.FirstPage(4)
+	for pages := 1; ; pages++ {
+		for _, e := range entries {
+			if e.Value*10 != e.Key {
+				t.Fatalf("Page entry = %+v", e)
+			}
+			keys = append(keys, e.Key)
+		}
+		if !more {
+			if pages != 3 {
+				t.Fatalf("got %d pages, want 3", pages)
+			}
+			break
+		}
+		entries, next, more = m.Page(next, 4)
+	}
+	if len(keys) != 10 || keys[0] != 10 || keys[9] != 100 {
+		t.Fatalf("paged keys = %v", keys)
+	}
+
+	// The after key need not exist.
+	entries, next, more = m.Page(55, 5)
+	if len(entries) != 5 || next != 100 || more {
+		t.Fatalf("Page(55, 5) = %v, %d, %v", entries, next, more)
+	}
+	entries, next, more = m.Page(100, 5)
+	if len(entries) != 0 || next != 100 || more {
+		t.Fatalf("Page(100, 5) = %v, %d, %v", entries, next, more)
+	}
+
+	mk := sortedmap.New[testKey, string]()
+	mk.Set(testKey{1, 1}, "a")
+	mk.Set(testKey{1, 2}, "b")
+	if entries, next, more := mk.Page(testKey{1, 1}, 1); len(entries) != 1 || next != (testKey{1, 2}) || more {
+		t.Fatalf("Map Page = %v, %v, %v", entries, next, more)
+	}
+
+	// a non-positive limit would never advance a paging loop
+	for _, limit := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Page(10, %d) did not panic", limit)
+				}
+			}()
+			m.Page(10, limit)
+		}()
+	}
+}
+
+func TestOrderedMapStats(t *testing.T) {
+	m := sortedmap.NewOrdered[int, int]()
+	for k := 0; k < 1000; k++ {
+		m.Set(k, k)
+	}
+	s := m.Stats()
+	if s.Len != 1000 || s.Levels[0] != 1000 || s.Levels[1] == 0 || s.ArrayBytes == 0 {
+		t.Fatalf("Stats = %+v", s)
+	}
+
+	sm := sortedmap.NewSyncOrdered[int, int]()
+	sm.Set(1, 1)
+	if s := sm.Stats(); s.Len != 1 {
+		t.Fatalf("SyncOrderedMap Stats Len = %d, want 1", s.Len)
+	}
+}
+
+func TestOrderedMapUpdate(t *testing.T) {
+	m := sortedmap.NewOrdered[string, int]()
+
+	calls := 0
+	one := func() int { calls++; return 1 }
+	a, inserted := m.GetOrInsert("a", one)
+	if !inserted || *a != 1 {
+		t.Fatalf("GetOrInsert new = %d, %v", *a, inserted)
+	}
+	a2, inserted := m.GetOrInsert("a", one)
+	if inserted || a2 != a || calls != 1 {
+		t.Fatalf("GetOrInsert existing = %d, %v after %d calls", *a2, inserted, calls)
+	}
+
+	if old, ok := m.Swap("a", 2); !ok || old != 1 || *a != 2 {
+		t.Fatalf("Swap existing = %d, %v", old, ok)
+	}
+	if old, ok := m.Swap("b", 3); ok || old != 0 || *m.Get("b") != 3 {
+		t.Fatalf("Swap new = %d, %v", old, ok)
+	}
+
+	incr := func(old *int, exists bool) (int, bool) {
+		if !exists {
+			return 100, true
+		}
+		return *old + 1, *old < 3
+	}
+	if v := m.Update("a", incr); v != a || *a != 3 {
+		t.Fatal("Update existing did not keep the value address")
+	}
+	if v := m.Update("c", incr); v == nil || *v != 100 {
+		t.Fatal("Update missing did not insert")
+	}
+	if v := m.Update("b", incr); v != nil || m.Get("b") != nil {
+		t.Fatal("Update did not delete b")
+	}
+	if v := m.Update("d", func(*int, bool) (int, bool) { return 0, false }); v != nil || m.Len() != 2 {
+		t.Fatal("Update without keep inserted d")
+	}
+
+	var keys []string
+	m.Range(func(k string, v *int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if !reflect.DeepEqual(keys, []string{"a", "c"}) {
+		t.Fatalf("keys = %v, want [a c]", keys)
+	}
+}
+
+func TestOrderedMapSample(t *testing.T) {
+	m := sortedmap.NewOrderedRanked[int, string]()
+	for k := 0; k < 1000; k++ {
+		m.Set(k, fmt.Sprint(k))
+	}
+
+	if k, v, ok := m.Quantile(0.5); !ok || k != 500 || *v != "500" {
+		t.Fatalf("Quantile(0.5) = %d, %v", k, ok)
+	}
+	entries := m.SampleN(4)
+	if len(entries) != 4 || entries[1].Key != 250 || entries[3].Value != "750" {
+		t.Fatalf("SampleN(4) = %v", entries)
+	}
+	entries = m.RandomSample(10, nil)
+	if len(entries) != 10 || entries[0].Value != fmt.Sprint(entries[0].Key) {
+		t.Fatalf("RandomSample(10) = %v", entries)
+	}
+
+	if _, _, ok := sortedmap.NewOrdered[int, string]().Quantile(0.5); ok {
+		t.Fatal("empty Quantile found an entry")
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import "github.com/someonegg/gocontainer/uskiplist"
+
+// Stats describes the shape and the memory usage of the skiplist under a
+// sorted map or set, see uskiplist.Stats.
+type Stats = uskiplist.Stats
+
+// Stats returns the statistics of the map, it takes O(n) time.
+func (m *Map[K, V]) Stats() Stats {
+	return m.list.Stats()
+}
+
+// Stats returns the statistics of the map, it takes O(n) time.
+func (m *OrderedMap[K, V]) Stats() Stats {
+	return m.list.Stats()
+}
+
+// Stats returns the statistics of the map, it takes O(n) time.
+func (m *FuncMap[K, V]) Stats() Stats {
+	return m.list.Stats()
+}
+
+// Stats returns the statistics of the map, it takes O(n) time.
+func (m *MultiMap[K, V]) Stats() Stats {
+	return m.list.Stats()
+}
+
+// Stats returns the statistics of the map, it takes O(n) time.
+func (m *OrderedMultiMap[K, V]) Stats() Stats {
+	return m.list.Stats()
+}
+
+// Stats returns the statistics of the set, it takes O(n) time.
+func (s *Set[K]) Stats() Stats {
+	return s.list.Stats()
+}
+
+// Stats returns the statistics of the set, it takes O(n) time.
+func (s *OrderedSet[K]) Stats() Stats {
+	return s.list.Stats()
+}
+
+// Stats returns the statistics of the map, it takes O(n) time and holds the
+// read lock meanwhile.
+func (m *SyncMap[K, V]) Stats() Stats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Stats()
+}
+
+// Stats returns the statistics of the map, it takes O(n) time and holds the
+// read lock meanwhile.
+func (m *SyncOrderedMap[K, V]) Stats() Stats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Stats()
+}
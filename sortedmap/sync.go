@@ -0,0 +1,329 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap
+
+import (
+	"sync"
+
+	"github.com/someonegg/gocontainer/cmp"
+)
+
+const syncBatchSize = 64
+
+// syncBatch holds entries copied under the read lock.
+type syncBatch[K, V any] struct {
+	keys   [syncBatchSize]K
+	values [syncBatchSize]V
+	n      int
+}
+
+func (b *syncBatch[K, V]) reset() {
+	var zk K
+	var zv V
+	for i := 0; i < b.n; i++ {
+		b.keys[i], b.values[i] = zk, zv
+	}
+	b.n = 0
+}
+
+// add copies e, it returns false when the batch is full.
+func (b *syncBatch[K, V]) add(e *entry[K, V]) bool {
+	b.keys[b.n] = e.key
+	b.values[b.n] = e.value
+	b.n++
+	return b.n < syncBatchSize
+}
+
+// flush calls fn for the copied entries, it returns true when the batch is
+// full and fn never returns false, which means more entries may follow.
+func (b *syncBatch[K, V]) flush(fn func(K, V) bool) bool {
+	for i := 0; i < b.n; i++ {
+		if !fn(b.keys[i], b.values[i]) {
+			return false
+		}
+	}
+	return b.n == syncBatchSize
+}
+
+// SyncMap is a Map safe for concurrent use by multiple goroutines.
+//
+// Range and RangeFrom do not hold the lock while calling fn. They copy entries
+// in small batches under the read lock, so a long scan never blocks writers
+// for more than one batch. While writers run, a range:
+//
+//   - visits keys in strictly ascending order, each key at most once;
+//   - visits every entry that is present during the whole range;
+//   - may or may not visit entries set or deleted concurrently;
+//   - passes the value an entry had when its batch was copied.
+//
+// fn may call any method of the map, including writes.
+type SyncMap[K cmp.Key[K], V any] struct {
+	mu sync.RWMutex
+	m  Map[K, V]
+}
+
+// NewSync creates and initializes a new concurrent sorted map.
+func NewSync[K cmp.Key[K], V any]() *SyncMap[K, V] {
+	m := &SyncMap[K, V]{}
+	m.m.Init()
+	return m
+}
+
+// Len returns number of entries in the map.
+func (m *SyncMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Len()
+}
+
+// Clear removes all entries from the map.
+func (m *SyncMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m.Clear()
+}
+
+// Get returns the value associated with k, or false when k is not found.
+func (m *SyncMap[K, V]) Get(k K) (v V, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if p := m.m.Get(k); p != nil {
+		return *p, true
+	}
+	return v, false
+}
+
+// Set sets the value for k.
+func (m *SyncMap[K, V]) Set(k K, v V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m.Set(k, v)
+}
+
+// Delete removes k from the map and returns the removed value.
+func (m *SyncMap[K, V]) Delete(k K) (old V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.Delete(k)
+}
+
+// Swap sets the value for k and returns the previous value, ok tells whether
+// k existed.
+func (m *SyncMap[K, V]) Swap(k K, v V) (old V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.Swap(k, v)
+}
+
+// GetOrInsert returns the value associated with k. When k is not found, it
+// inserts the value returned by fn, and inserted is true.
+//
+// fn is called under the write lock, it must not call any method of the map.
+func (m *SyncMap[K, V]) GetOrInsert(k K, fn func() V) (v V, inserted bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, inserted := m.m.GetOrInsert(k, fn)
+	return *p, inserted
+}
+
+// Update calls fn with the value associated with k, exists is false when k is
+// not found. The value returned by fn is stored when keep is true, otherwise
+// k is removed. It returns the stored value, or false when there is none.
+//
+// fn is called under the write lock, so no other write comes between reading
+// old and storing v. It must not call any method of the map.
+func (m *SyncMap[K, V]) Update(k K, fn func(old V, exists bool) (v V, keep bool)) (v V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := m.m.Update(k, func(old *V, exists bool) (V, bool) {
+		var v V
+		if exists {
+			v = *old
+		}
+		return fn(v, exists)
+	})
+	if p == nil {
+		return v, false
+	}
+	return *p, true
+}
+
+// Range calls fn once for each entry in ascending key order. See SyncMap for
+// what it observes while writers run.
+//
+// It stops whenever fn returns false.
+func (m *SyncMap[K, V]) Range(fn func(K, V) bool) {
+	b := &syncBatch[K, V]{}
+	m.mu.RLock()
+	m.m.list.Iterate(b.add)
+	m.mu.RUnlock()
+	m.scan(b, fn)
+}
+
+// RangeFrom calls fn once for each entry with key greater than or equal to
+// pivot, in ascending key order. See SyncMap for what it observes while writers
+// run.
+//
+// It stops whenever fn returns false.
+func (m *SyncMap[K, V]) RangeFrom(pivot K, fn func(K, V) bool) {
+	b := &syncBatch[K, V]{}
+	m.mu.RLock()
+	m.m.list.IterateFrom(pivot, b.add)
+	m.mu.RUnlock()
+	m.scan(b, fn)
+}
+
+// scan calls fn for the first batch b, then continues with the following
+// batches.
+func (m *SyncMap[K, V]) scan(b *syncBatch[K, V], fn func(K, V) bool) {
+	for b.flush(fn) {
+		after := b.keys[b.n-1]
+		b.reset()
+		m.mu.RLock()
+		m.m.list.IterateAfter(after, b.add)
+		m.mu.RUnlock()
+	}
+}
+
+// SyncOrderedMap is an OrderedMap safe for concurrent use by multiple
+// goroutines.
+//
+// Range and RangeFrom do not hold the lock while calling fn. They copy entries
+// in small batches under the read lock, so a long scan never blocks writers
+// for more than one batch. While writers run, a range:
+//
+//   - visits keys in strictly ascending order, each key at most once;
+//   - visits every entry that is present during the whole range;
+//   - may or may not visit entries set or deleted concurrently;
+//   - passes the value an entry had when its batch was copied.
+//
+// fn may call any method of the map, including writes.
+type SyncOrderedMap[K cmp.Ordered, V any] struct {
+	mu sync.RWMutex
+	m  OrderedMap[K, V]
+}
+
+// NewSyncOrdered creates and initializes a new concurrent sorted map for ordered keys.
+func NewSyncOrdered[K cmp.Ordered, V any]() *SyncOrderedMap[K, V] {
+	m := &SyncOrderedMap[K, V]{}
+	m.m.Init()
+	return m
+}
+
+// Len returns number of entries in the map.
+func (m *SyncOrderedMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Len()
+}
+
+// Clear removes all entries from the map.
+func (m *SyncOrderedMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m.Clear()
+}
+
+// Get returns the value associated with k, or false when k is not found.
+func (m *SyncOrderedMap[K, V]) Get(k K) (v V, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if p := m.m.Get(k); p != nil {
+		return *p, true
+	}
+	return v, false
+}
+
+// Set sets the value for k.
+func (m *SyncOrderedMap[K, V]) Set(k K, v V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m.Set(k, v)
+}
+
+// Delete removes k from the map and returns the removed value.
+func (m *SyncOrderedMap[K, V]) Delete(k K) (old V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.Delete(k)
+}
+
+// Swap sets the value for k and returns the previous value, ok tells whether
+// k existed.
+func (m *SyncOrderedMap[K, V]) Swap(k K, v V) (old V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.Swap(k, v)
+}
+
+// GetOrInsert returns the value associated with k. When k is not found, it
+// inserts the value returned by fn, and inserted is true.
+//
+// fn is called under the write lock, it must not call any method of the map.
+func (m *SyncOrderedMap[K, V]) GetOrInsert(k K, fn func() V) (v V, inserted bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, inserted := m.m.GetOrInsert(k, fn)
+	return *p, inserted
+}
+
+// Update calls fn with the value associated with k, exists is false when k is
+// not found. The value returned by fn is stored when keep is true, otherwise
+// k is removed. It returns the stored value, or false when there is none.
+//
+// fn is called under the write lock, so no other write comes between reading
+// old and storing v. It must not call any method of the map.
+func (m *SyncOrderedMap[K, V]) Update(k K, fn func(old V, exists bool) (v V, keep bool)) (v V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := m.m.Update(k, func(old *V, exists bool) (V, bool) {
+		var v V
+		if exists {
+			v = *old
+		}
+		return fn(v, exists)
+	})
+	if p == nil {
+		return v, false
+	}
+	return *p, true
+}
+
+// Range calls fn once for each entry in ascending key order. See SyncOrderedMap for
+// what it observes while writers run.
+//
+// It stops whenever fn returns false.
+func (m *SyncOrderedMap[K, V]) Range(fn func(K, V) bool) {
+	b := &syncBatch[K, V]{}
+	m.mu.RLock()
+	m.m.list.Iterate(b.add)
+	m.mu.RUnlock()
+	m.scan(b, fn)
+}
+
+// RangeFrom calls fn once for each entry with key greater than or equal to
+// pivot, in ascending key order. See SyncOrderedMap for what it observes while writers
+// run.
+//
+// It stops whenever fn returns false.
+func (m *SyncOrderedMap[K, V]) RangeFrom(pivot K, fn func(K, V) bool) {
+	b := &syncBatch[K, V]{}
+	m.mu.RLock()
+	m.m.list.IterateFrom(pivot, b.add)
+	m.mu.RUnlock()
+	m.scan(b, fn)
+}
+
+// scan calls fn for the first batch b, then continues with the following
+// batches.
+func (m *SyncOrderedMap[K, V]) scan(b *syncBatch[K, V], fn func(K, V) bool) {
+	for b.flush(fn) {
+		after := b.keys[b.n-1]
+		b.reset()
+		m.mu.RLock()
+		m.m.list.IterateAfter(after, b.add)
+		m.mu.RUnlock()
+	}
+}
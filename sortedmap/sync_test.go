@@ -0,0 +1,188 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortedmap_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/someonegg/gocontainer/sortedmap"
+)
+
+func TestSyncOrderedMap(t *testing.T) {
+	m := sortedmap.NewSyncOrdered[int, int]()
+
+	// Even keys are stable, odd keys are set and deleted by writers.
+	const n = 2000
+	for k := 0; k < n; k += 2 {
+		m.Set(k, k)
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				k := r.Intn(n/2)*2 + 1
+				if r.Intn(2) == 0 {
+					m.Set(k, k)
+				} else {
+					m.Delete(k)
+				}
+			}
+		}(int64(w))
+	}
+
+	var readers sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		readers.Add(1)
+		go func(r int) {
+			defer readers.Done()
+			for i := 0; i < 20; i++ {
+				pivot := 0
+				if r%2 == 1 {
+					pivot = n / 2
+				}
+
+				last, stable := -1, 0
+				m.RangeFrom(pivot, func(k, v int) bool {
+					if k <= last {
+						t.Errorf("RangeFrom visited %d after %d", k, last)
+					}
+					if k != v {
+						t.Errorf("RangeFrom value of %d = %d", k, v)
+					}
+					if k%2 == 0 {
+						stable++
+					}
+					last = k
+					return true
+				})
+				if want := (n - pivot) / 2; stable != want {
+					t.Errorf("RangeFrom(%d) visited %d stable keys, want %d", pivot, stable, want)
+				}
+
+				if v, ok := m.Get(pivot); !ok || v != pivot {
+					t.Errorf("Get(%d) = (%d, %v)", pivot, v, ok)
+				}
+			}
+		}(r)
+	}
+
+	readers.Wait()
+	close(stop)
+	wg.Wait()
+
+	// fn may write to the map.
+	m.Range(func(k, _ int) bool {
+		if k%2 == 1 {
+			m.Delete(k)
+		}
+		return true
+	})
+	if m.Len() != n/2 {
+		t.Fatalf("Len = %d, want %d", m.Len(), n/2)
+	}
+
+	visited := 0
+	m.Range(func(int, int) bool {
+		visited++
+		return visited < 100
+	})
+	if visited != 100 {
+		t.Fatalf("Range visited %d after stop, want 100", visited)
+	}
+
+	m.Clear()
+	if m.Len() != 0 {
+		t.Fatalf("Len after Clear = %d, want 0", m.Len())
+	}
+}
+
+func TestSyncMap(t *testing.T) {
+	m := sortedmap.NewSync[testKey, string]()
+	m.Set(testKey{major: 2}, "b")
+	m.Set(testKey{major: 1}, "a")
+
+	var values []string
+	m.Range(func(_ testKey, v string) bool {
+		values = append(values, v)
+		return true
+	})
+	if len(values) != 2 || values[0] != "a" || values[1] != "b" {
+		t.Fatalf("Range values = %v, want [a b]", values)
+	}
+	if old, ok := m.Delete(testKey{major: 1}); !ok || old != "a" {
+		t.Fatalf("Delete = (%q, %v), want (a, true)", old, ok)
+	}
+	if _, ok := m.Get(testKey{major: 1}); ok {
+		t.Fatal("Get deleted key returned ok")
+	}
+
+	if old, ok := m.Swap(testKey{major: 2}, "c"); !ok || old != "b" {
+		t.Fatalf("Swap = (%q, %v), want (b, true)", old, ok)
+	}
+	if v, inserted := m.GetOrInsert(testKey{major: 2}, func() string { return "x" }); inserted || v != "c" {
+		t.Fatalf("GetOrInsert = (%q, %v), want (c, false)", v, inserted)
+	}
+	v, ok := m.Update(testKey{major: 3}, func(old string, exists bool) (string, bool) {
+		return old + "d", !exists
+	})
+	if !ok || v != "d" {
+		t.Fatalf("Update = (%q, %v), want (d, true)", v, ok)
+	}
+	if _, ok := m.Update(testKey{major: 3}, func(string, bool) (string, bool) { return "", false }); ok || m.Len() != 1 {
+		t.Fatalf("Update removal = %v, Len %d", ok, m.Len())
+	}
+}
+
+func TestSyncOrderedMapUpdate(t *testing.T) {
+	m := sortedmap.NewSyncOrdered[int, int]()
+
+	// Each writer increments every counter and inserts every key once, no
+	// write may be lost between reading and storing a value.
+	const writers = 8
+	const keys = 100
+	var inserts [keys]int // fn runs under the write lock
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < keys; k++ {
+				m.Update(k, func(old int, exists bool) (int, bool) {
+					return old + 1, true
+				})
+				m.GetOrInsert(-1-k, func() int {
+					inserts[k]++
+					return k
+				})
+				m.Swap(keys+k, k)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for k := 0; k < keys; k++ {
+		if v, _ := m.Get(k); v != writers {
+			t.Fatalf("counter %d = %d, want %d", k, v, writers)
+		}
+		if inserts[k] != 1 {
+			t.Fatalf("GetOrInsert(%d) called fn %d times, want 1", -1-k, inserts[k])
+		}
+	}
+	if m.Len() != 3*keys {
+		t.Fatalf("Len = %d, want %d", m.Len(), 3*keys)
+	}
+}
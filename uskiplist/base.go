@@ -7,12 +7,13 @@
 package uskiplist
 
 import (
-	"time"
+	"math"
+	"math/rand"
 	"unsafe"
 )
 
 const (
-	// PROPABILITY is the fixed probability.
+	// PROPABILITY is the default probability.
 	PROPABILITY float32 = 0.25
 
 	// Level limit will increase dynamically.
@@ -40,40 +41,185 @@ func (e *Embedder[E]) lnNext() *leveln[E] {
 	return (*leveln[E])(e.next)
 }
 
+// Reset clears the links of an element which is not in a skiplist, so a
+// recycled element does not keep its old point array alive.
+func (e *Embedder[E]) Reset() {
+	e.next = nil
+}
+
 type Iterator[E any] func(*E) bool
 
 type searchPath[E any] [MaximumLevel]**E
 
-type listBase[E any] struct {
+// pathArray returns the point array which path[i] belongs to, path[i] must
+// not be a level 1 slot.
+func pathArray[E any](path *searchPath[E], i int) *leveln[E] {
+	return (*leveln[E])(unsafe.Add(unsafe.Pointer(path[i]), -i*int(ptrSize)))
+}
+
+// linker is the key independent part of Element, ElementO and ElementF.
+type linker[E any] interface {
+	*E
+
+	ptNext() *unsafe.Pointer
+	l1Next() *level1[E]
+	lnNext() *leveln[E]
+}
+
+// Options configures a skiplist, the zero value is the default.
+type Options struct {
+	// Ranked maintains the span of each forward pointer, so elements can be
+	// located by position in O(log n). It costs an extra span array for each
+	// element with more than one level.
+	Ranked bool
+
+	// Duplicates allows elements with equal keys, they are kept in insertion
+	// order. Get, Delete, Remove, Replace and IndexOf refer to the first one of
+	// them.
+	Duplicates bool
+
+	// Source generates the levels of elements, a seeded source (see
+	// NewSource) makes the shape of the skiplist reproducible. When nil, each
+	// skiplist uses its own generator with a random seed.
+	Source rand.Source64
+
+	// Probability is the chance that an element gets one more level, it must
+	// be in (0, 1). A higher one gives faster searches and more pointers per
+	// element. When zero, PROPABILITY is used.
+	Probability float32
+
+	// LevelLimit returns the level limit for a skiplist of n elements, the
+	// result is clamped to [2, MaximumLevel]. When nil, the limit is 4, 8, 16
+	// or 32, stepping up when n reaches (1/p)^3.5, (1/p)^7.5 and (1/p)^15.5.
+	LevelLimit func(n int) int
+
+	// NoShrink keeps the level limit after deletions. By default it drops
+	// when the skiplist shrinks to less than a quarter of the size the limit
+	// was raised at, and the pointers above the new limit are cleared.
+	NoShrink bool
+
+	// Pool recycles the point arrays of elements with more than one level,
+	// see ArrayPool. When set, a removed element gives its array back to the
+	// pool and is left with no links, as if Reset.
+	Pool *ArrayPool
+}
+
+type listBase[E any, PE linker[E]] struct {
+	opts Options
 	maxL int
 	len  int
 	root *leveln[E]
 	rnd  splitMix64
+
+	// rootL is the length of the root array, shrink keeps it longer than
+	// maxL.
+	rootL int
+
+	// derived from the probability, see setup.
+	thresh int64
+	factor int
+	steps  [3]int
+
+	// mods counts the changes of links, so a Cursor knows when it must
+	// search again.
+	mods uint
 }
 
-func (l *listBase[E]) init() {
-	l.maxL = InitialLevel
+func (l *listBase[E, PE]) init() {
+	l.setup()
 	l.len = 0
-	l.root = (*leveln[E])(makePointArray(InitialLevel))
-	l.rnd = splitMix64(time.Now().Unix())
+	l.mods++
+	l.maxL = l.levelFor(0)
+	l.rootL = l.maxL
+	l.root = (*leveln[E])(l.makeArray(l.maxL))
+	l.rnd = splitMix64(newSeed())
+}
+
+// setup derives the level parameters from the probability.
+func (l *listBase[E, PE]) setup() {
+	p := l.probability()
+	if !(p > 0 && p < 1) {
+		panic("probability out of range")
+	}
+
+	const RANDMAX int64 = 65536
+	l.thresh = int64(float32(RANDMAX) * p)
+
+	l.factor = int(math.Round(1 / float64(p)))
+	if l.factor < 2 {
+		l.factor = 2
+	}
+
+	for i, exp := range [3]float64{3.5, 7.5, 15.5} {
+		n := math.Round(math.Pow(1/float64(p), exp))
+		if n > 1<<62 {
+			n = 1 << 62
+		}
+		l.steps[i] = int(n)
+	}
+}
+
+// sampleLevel returns the level whose elements are about step apart.
+//
+// [2, l.maxL]
+func (l *listBase[E, PE]) sampleLevel(step int) int {
+	p := float64(l.probability())
+	lev := int(math.Round(math.Log(float64(step))/math.Log(1/p) + 1.0))
+	if lev < 2 {
+		lev = 2
+	}
+	if lev > l.maxL {
+		lev = l.maxL
+	}
+	return lev
+}
+
+func (l *listBase[E, PE]) probability() float32 {
+	if l.opts.Probability == 0 {
+		return PROPABILITY
+	}
+	return l.opts.Probability
+}
+
+// Options returns the options of the skiplist.
+func (l *listBase[E, PE]) Options() Options {
+	return l.opts
+}
+
+func (l *listBase[E, PE]) makeArray(n int) unsafe.Pointer {
+	if l.opts.Pool != nil {
+		return l.opts.Pool.get(n, l.opts.Ranked)
+	}
+	if l.opts.Ranked {
+		return makeRankedArray(n)
+	}
+	return makePointArray(n)
+}
+
+// [2, MaximumLevel]
+func (l *listBase[E, PE]) idealLevel() int {
+	return l.levelFor(l.len)
 }
 
-// [InitialLevel, MaximumLevel]
-func (l *listBase[E]) idealLevel() int {
-	// hardcode
+// levelFor returns the level limit for a skiplist of n elements.
+//
+// [2, MaximumLevel]
+func (l *listBase[E, PE]) levelFor(n int) int {
 	var lev int
 	switch {
-	case l.len < 128:
+	case l.opts.LevelLimit != nil:
+		lev = l.opts.LevelLimit(n)
+	case n < l.steps[0]:
 		lev = 4
-	case l.len < 128*256:
+	case n < l.steps[1]:
 		lev = 8
-	case l.len < 128*256*256*256:
+	case n < l.steps[2]:
 		lev = 16
 	default:
 		lev = 32
 	}
-	if lev < InitialLevel {
-		lev = InitialLevel
+	if lev < 2 {
+		lev = 2
 	}
 	if lev > MaximumLevel {
 		lev = MaximumLevel
@@ -81,25 +227,315 @@ func (l *listBase[E]) idealLevel() int {
 	return lev
 }
 
-func (l *listBase[E]) adjust() {
+// adjust raises the level limit when the skiplist has grown, the root array
+// is replaced only when it is too short.
+func (l *listBase[E, PE]) adjust() {
 	ideal := l.idealLevel()
-	if ideal > l.maxL {
-		lev := l.maxL
+	if ideal <= l.maxL {
+		return
+	}
+
+	lev := l.maxL
+	if ideal > l.rootL {
 		root := l.root
-		l.maxL = ideal
-		l.root = (*leveln[E])(makePointArray(l.maxL))
+		l.root = (*leveln[E])(l.makeArray(ideal))
 		for i := 0; i < lev; i++ {
 			l.root[i] = root[i]
 		}
+		if l.opts.Ranked {
+			spans, old := spansOf(l.root), spansOf(root)
+			for i := 1; i < lev; i++ {
+				spans[i] = old[i]
+			}
+		}
+		if l.opts.Pool != nil {
+			l.opts.Pool.put(unsafe.Pointer(root), l.opts.Ranked)
+		}
+		l.rootL = ideal
+	}
+
+	l.maxL = ideal
+	if l.opts.Ranked {
+		spans := spansOf(l.root)
+		for i := lev; i < l.maxL; i++ {
+			spans[i] = l.len
+		}
+	}
+}
+
+// repath fixes path after adjust has raised the level limit from maxL, and
+// maybe replaced root.
+func (l *listBase[E, PE]) repath(path *searchPath[E], root *leveln[E], maxL int) {
+	if l.root != root {
+		for i := 0; i < maxL; i++ {
+			if path[i] == &root[i] {
+				path[i] = &l.root[i]
+			}
+		}
+	}
+	for i := maxL; i < l.maxL; i++ {
+		path[i] = &l.root[i]
+	}
+}
+
+func (l *listBase[E, PE]) int63() int64 {
+	if l.opts.Source != nil {
+		return l.opts.Source.Int63()
+	}
+	return l.rnd.Int63()
+}
+
+// shrink lowers the level limit when the skiplist has shrunk far below it.
+// The root array is kept for adjust to grow back into, only the pointers
+// above the new limit are cleared.
+func (l *listBase[E, PE]) shrink() {
+	if l.opts.NoShrink || l.levelFor(l.len*4) >= l.maxL {
+		return
+	}
+
+	lev := l.levelFor(l.len)
+	for i := l.maxL - 1; i >= lev; i-- {
+		cur := l.root[i]
+		for cur != nil {
+			ln := PE(cur).lnNext()
+			cur, ln[i] = ln[i], nil
+		}
+		l.root[i] = nil
 	}
+	l.maxL = lev
 }
 
-func (l *listBase[E]) randLevel() int {
+func (l *listBase[E, PE]) randLevel() int {
 	const RANDMAX int64 = 65536
-	const RANDTHRESHOLD int64 = int64(float32(RANDMAX) * PROPABILITY)
 	lev := 1
-	for l.rnd.Int63()%RANDMAX < RANDTHRESHOLD && lev < l.maxL {
+	for l.int63()%RANDMAX < l.thresh && lev < l.maxL {
 		lev++
 	}
 	return lev
 }
+
+// link links e at the position of path, which must be filled for all levels.
+func (l *listBase[E, PE]) link(e *E, path *searchPath[E]) {
+	lev := l.randLevel()
+
+	// fast path
+	if lev == 1 {
+		l1 := PE(e).l1Next()
+		l1[0] = *path[0]
+		*path[0] = e
+		if l.opts.Ranked {
+			for i := 1; i < l.maxL; i++ {
+				pathSpans(path, i)[i]++
+			}
+		}
+		l.len++
+		l.mods++
+		return
+	}
+
+	*(PE(e).ptNext()) = l.makeArray(lev)
+
+	ln := PE(e).lnNext()
+	if l.opts.Ranked {
+		ranks := &rankPath{}
+		l.pathRanks(path, ranks)
+
+		spans := spansOf(ln)
+		for i := 1; i < lev; i++ {
+			pre := pathSpans(path, i)
+			d := ranks[0] - ranks[i]
+			spans[i] = pre[i] - d
+			pre[i] = d + 1
+		}
+		for i := lev; i < l.maxL; i++ {
+			pathSpans(path, i)[i]++
+		}
+	}
+
+	for i := lev - 1; i >= 0; i-- {
+		ln[i] = *path[i]
+		*path[i] = e
+	}
+
+	l.len++
+	l.mods++
+	l.adjust()
+}
+
+// unlink unlinks e, which must be the target of path[0], path must be filled
+// for all levels.
+func (l *listBase[E, PE]) unlink(e *E, path *searchPath[E]) {
+	// fast path
+	if *path[1] != e {
+		l1 := PE(e).l1Next()
+		*path[0] = l1[0]
+		l1[0] = nil
+		if l.opts.Ranked {
+			for i := 1; i < l.maxL; i++ {
+				pathSpans(path, i)[i]--
+			}
+		}
+		l.len--
+		l.mods++
+		l.shrink()
+		return
+	}
+
+	ln := PE(e).lnNext()
+	if l.opts.Ranked {
+		spans := spansOf(ln)
+		for i := l.maxL - 1; i > 0; i-- {
+			pre := pathSpans(path, i)
+			if *path[i] == e {
+				pre[i] += spans[i] - 1
+			} else {
+				pre[i]--
+			}
+		}
+	}
+
+	for i := l.maxL - 1; i >= 0; i-- {
+		if *path[i] == e {
+			*path[i] = ln[i]
+			ln[i] = nil
+		}
+	}
+	if l.opts.Pool != nil {
+		l.opts.Pool.put(*(PE(e).ptNext()), l.opts.Ranked)
+		*(PE(e).ptNext()) = nil
+	}
+
+	l.len--
+	l.mods++
+	l.shrink()
+}
+
+// replace links n in place of e, which must be the target of path[0], path
+// must be filled for all levels. n takes over the levels (and spans) of e.
+func (l *listBase[E, PE]) replace(e, n *E, path *searchPath[E]) {
+	*(PE(n).ptNext()) = *(PE(e).ptNext())
+	*(PE(e).ptNext()) = nil
+	for i := l.maxL - 1; i >= 0; i-- {
+		if *path[i] == e {
+			*path[i] = n
+		}
+	}
+	l.mods++
+}
+
+func (l *listBase[E, PE]) iterate(cur, relay *E, iterator Iterator[E]) {
+	for {
+		for cur != nil {
+			save := cur
+
+			l1 := PE(cur).l1Next()
+			cur = l1[0]
+			if cur == relay {
+				cur = nil
+			}
+
+			if !iterator(save) {
+				return
+			}
+		}
+
+		if relay == nil {
+			break
+		}
+
+		save := relay
+
+		ln := PE(relay).lnNext()
+		if ln[0] != ln[1] {
+			cur = ln[0]
+		}
+		relay = ln[1]
+
+		if !iterator(save) {
+			return
+		}
+	}
+}
+
+// revFrame is a pending element of a reverse iteration. The elements between
+// the previous frame and e are linked only below level lev, and have not been
+// pushed yet.
+type revFrame[E any] struct {
+	e   *E
+	lev int
+}
+
+// revStack returns a reverse iteration stack holding only the head frame.
+func (l *listBase[E, PE]) revStack() []revFrame[E] {
+	return make([]revFrame[E], 1, 4*l.maxL)
+}
+
+// next returns the successor of e at level i, e must be the head (nil) or
+// have a pointer array.
+func (l *listBase[E, PE]) next(e *E, i int) *E {
+	if e == nil {
+		return l.root[i]
+	}
+	return PE(e).lnNext()[i]
+}
+
+// pushTail pushes all elements behind the stack top in ascending order.
+func (l *listBase[E, PE]) pushTail(stack []revFrame[E]) []revFrame[E] {
+	for i := l.maxL - 1; i >= 0; i-- {
+		cur := l.next(stack[len(stack)-1].e, i)
+		for cur != nil {
+			stack = append(stack, revFrame[E]{cur, i})
+			if i > 0 {
+				cur = PE(cur).lnNext()[i]
+			} else {
+				cur = PE(cur).l1Next()[0]
+			}
+		}
+	}
+	return stack
+}
+
+// reverse pops the stack and calls iterator once for each element in
+// descending order, the gaps between frames are filled on demand.
+//
+//	The current element can be deleted in Iterator, because the gap before
+//	it has been filled before it is visited. A deletion may also lower the
+//	level limit, so the gaps are filled below it.
+func (l *listBase[E, PE]) reverse(stack []revFrame[E], iterator Iterator[E]) {
+	for len(stack) > 1 {
+		t := len(stack) - 1
+		top := stack[t]
+
+		if top.lev > 0 {
+			i := top.lev - 1
+			if i >= l.maxL {
+				i = l.maxL - 1
+			}
+			stack = stack[:t]
+			cur := l.next(stack[t-1].e, i)
+			for cur != top.e {
+				stack = append(stack, revFrame[E]{cur, i})
+				if i > 0 {
+					cur = PE(cur).lnNext()[i]
+				} else {
+					cur = PE(cur).l1Next()[0]
+				}
+			}
+			stack = append(stack, revFrame[E]{top.e, i})
+			continue
+		}
+
+		stack = stack[:t]
+		if !iterator(top.e) {
+			return
+		}
+	}
+}
+
+// IterateReverse will call iterator once for each element in descending order.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *listBase[E, PE]) IterateReverse(iterator Iterator[E]) {
+	l.reverse(l.pushTail(l.revStack()), iterator)
+}
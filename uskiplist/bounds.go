@@ -0,0 +1,22 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist
+
+// Bounds tells which ends of a key range [lo, hi] are included.
+type Bounds uint8
+
+const (
+	// IncludeLo includes lo in the range.
+	IncludeLo Bounds = 1 << iota
+	// IncludeHi includes hi in the range.
+	IncludeHi
+
+	// Open excludes both ends: (lo, hi).
+	Open Bounds = 0
+	// HalfOpen includes lo only: [lo, hi).
+	HalfOpen = IncludeLo
+	// Closed includes both ends: [lo, hi].
+	Closed = IncludeLo | IncludeHi
+)
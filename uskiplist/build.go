@@ -0,0 +1,256 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist
+
+import "github.com/someonegg/gocontainer/cmp"
+
+// BuildOptions configures a Builder, the zero value is the default.
+type BuildOptions struct {
+	// Deterministic assigns levels by position instead of at random, so the
+	// element at position n (1-based) gets one more level for each factor
+	// of 1/p in n (rounded, at least 2), 4 by default. It gives a perfectly
+	// balanced skiplist.
+	Deterministic bool
+
+	// Strict panics on out of order input instead of rejecting it.
+	Strict bool
+}
+
+// appender is the key independent part of the builders, it links
+// elements at the end of a skiplist.
+type appender[E any, PE linker[E]] struct {
+	l     *listBase[E, PE]
+	opts  BuildOptions
+	path  searchPath[E]
+	ranks rankPath
+	last  *E
+}
+
+// init fills the path for the end of the skiplist.
+func (a *appender[E, PE]) init(l *listBase[E, PE], opts BuildOptions) {
+	a.l = l
+	a.opts = opts
+
+	ln := l.root
+	for i := l.maxL - 1; i > 0; i-- {
+		for ln[i] != nil {
+			a.last = ln[i]
+			ln = PE(a.last).lnNext()
+		}
+		a.path[i] = &ln[i]
+	}
+
+	slot := &ln[0]
+	for *slot != nil {
+		a.last = *slot
+		slot = &PE(a.last).l1Next()[0]
+	}
+	a.path[0] = slot
+
+	if l.opts.Ranked {
+		l.pathRanks(&a.path, &a.ranks)
+	}
+}
+
+func (a *appender[E, PE]) level() int {
+	l := a.l
+	if !a.opts.Deterministic {
+		return l.randLevel()
+	}
+
+	lev := 1
+	for n := l.len + 1; n%l.factor == 0 && lev < l.maxL; n /= l.factor {
+		lev++
+	}
+	return lev
+}
+
+// reject handles an out of order element.
+func (a *appender[E, PE]) reject() bool {
+	if a.opts.Strict {
+		panic("element out of order")
+	}
+	return false
+}
+
+// append links e after the last element.
+func (a *appender[E, PE]) append(e *E) {
+	l := a.l
+	lev := a.level()
+
+	if l.opts.Ranked {
+		rank := l.len + 1
+		for i := 1; i < l.maxL; i++ {
+			pre := pathSpans(&a.path, i)
+			if i < lev {
+				pre[i] = rank - a.ranks[i]
+				a.ranks[i] = rank
+			} else {
+				pre[i]++
+			}
+		}
+	}
+
+	if lev == 1 {
+		l1 := PE(e).l1Next()
+		l1[0] = nil
+		*a.path[0] = e
+		a.path[0] = &l1[0]
+	} else {
+		*(PE(e).ptNext()) = l.makeArray(lev)
+		ln := PE(e).lnNext()
+		for i := 0; i < lev; i++ {
+			*a.path[i] = e
+			a.path[i] = &ln[i]
+		}
+	}
+
+	a.last = e
+	l.len++
+	l.mods++
+
+	root, maxL := l.root, l.maxL
+	l.adjust()
+	l.repath(&a.path, root, maxL)
+	for i := maxL; i < l.maxL; i++ {
+		a.ranks[i] = 0
+	}
+}
+
+// Builder appends elements in ascending key order to the end of a List in
+// O(1) amortized time, without searching.
+//
+// The List must not be modified by other means while the Builder is in use.
+type Builder[K cmp.Key[K], E any, PE Element[K, E]] struct {
+	appender[E, PE]
+}
+
+// NewBuilder creates a Builder which appends to l.
+func NewBuilder[K cmp.Key[K], E any, PE Element[K, E]](l *List[K, E, PE], opts BuildOptions) *Builder[K, E, PE] {
+	b := &Builder[K, E, PE]{}
+	b.init(&l.listBase, opts)
+	return b
+}
+
+// Append appends e after the last element. It returns false, or panics when
+// Strict, if the key of e is not greater than the last one (not less than
+// when duplicates are allowed).
+func (b *Builder[K, E, PE]) Append(e *E) bool {
+	if b.last != nil {
+		k, last := PE(e).Key(), PE(b.last).Key()
+		if b.l.opts.Duplicates && k.Less(last) ||
+			!b.l.opts.Duplicates && !last.Less(k) {
+			return b.reject()
+		}
+	}
+
+	b.append(e)
+	return true
+}
+
+// BuildFromSorted reinitializes the skiplist, keeping the options, with the
+// elements in ascending key order in O(n) time. It returns the number of
+// elements linked, out of order elements are skipped or panic, see Builder.
+func (l *List[K, E, PE]) BuildFromSorted(elems []*E, opts BuildOptions) int {
+	l.Init()
+	b := NewBuilder(l, opts)
+
+	n := 0
+	for _, e := range elems {
+		if b.Append(e) {
+			n++
+		}
+	}
+	return n
+}
+
+// BuilderO is like Builder, but for ListO.
+type BuilderO[K cmp.Ordered, E any, PE ElementO[K, E]] struct {
+	appender[E, PE]
+}
+
+// NewBuilderO creates a BuilderO which appends to l.
+func NewBuilderO[K cmp.Ordered, E any, PE ElementO[K, E]](l *ListO[K, E, PE], opts BuildOptions) *BuilderO[K, E, PE] {
+	b := &BuilderO[K, E, PE]{}
+	b.init(&l.listBase, opts)
+	return b
+}
+
+// Append appends e after the last element. It returns false, or panics when
+// Strict, if the key of e is not greater than the last one (not less than
+// when duplicates are allowed).
+func (b *BuilderO[K, E, PE]) Append(e *E) bool {
+	if b.last != nil {
+		k, last := PE(e).Key(), PE(b.last).Key()
+		if b.l.opts.Duplicates && k < last ||
+			!b.l.opts.Duplicates && !(last < k) {
+			return b.reject()
+		}
+	}
+
+	b.append(e)
+	return true
+}
+
+// BuildFromSorted reinitializes the skiplist, keeping the options, with the
+// elements in ascending key order in O(n) time. It returns the number of
+// elements linked, out of order elements are skipped or panic, see BuilderO.
+func (l *ListO[K, E, PE]) BuildFromSorted(elems []*E, opts BuildOptions) int {
+	l.Init()
+	b := NewBuilderO(l, opts)
+
+	n := 0
+	for _, e := range elems {
+		if b.Append(e) {
+			n++
+		}
+	}
+	return n
+}
+
+// BuilderF is like Builder, but for ListF.
+type BuilderF[K any, E any, PE ElementF[K, E]] struct {
+	appender[E, PE]
+	less func(a, b K) bool
+}
+
+// NewBuilderF creates a BuilderF which appends to l.
+func NewBuilderF[K any, E any, PE ElementF[K, E]](l *ListF[K, E, PE], opts BuildOptions) *BuilderF[K, E, PE] {
+	b := &BuilderF[K, E, PE]{less: l.less}
+	b.init(&l.listBase, opts)
+	return b
+}
+
+// Append appends e after the last element. It returns false, or panics when
+// Strict, if the key of e is not greater than the last one (not less than
+// when duplicates are allowed).
+func (b *BuilderF[K, E, PE]) Append(e *E) bool {
+	if b.last != nil {
+		k, last := PE(e).Key(), PE(b.last).Key()
+		if b.l.opts.Duplicates && b.less(k, last) ||
+			!b.l.opts.Duplicates && !b.less(last, k) {
+			return b.reject()
+		}
+	}
+
+	b.append(e)
+	return true
+}
+
+// BuildFromSorted reinitializes the skiplist, keeping the options, with the
+// elements in ascending key order in O(n) time. It returns the number of
+// elements linked, out of order elements are skipped or panic, see BuilderF.
+func (l *ListF[K, E, PE]) BuildFromSorted(elems []*E, opts BuildOptions) int {
+	l.Init()
+	b := NewBuilderF(l, opts)
+
+	n := 0
+	for _, e := range elems {
+		if b.Append(e) {
+			n++
+		}
+	}
+	return n
+}
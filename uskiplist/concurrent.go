@@ -0,0 +1,326 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+
+	"github.com/someonegg/gocontainer/cmp"
+)
+
+// ConcurrentListO is a lock-free skiplist (Herlihy & Shavit), it is safe for
+// concurrent use by multiple goroutines.
+//
+// Each forward pointer is an immutable link swapped with CAS, and a deleted
+// element is first marked at every level, then unlinked by any goroutine
+// that passes it. Elements are visited in ascending order, an element inserted
+// or deleted during an iteration may or may not be visited.
+//
+// An element must not be inserted again after it has been deleted, because
+// concurrent readers may still be traversing it.
+type ConcurrentListO[K cmp.Ordered, E any, PE ConcurrentElementO[K, E]] struct {
+	head  [MaximumLevel]atomic.Pointer[clink[E]]
+	level atomic.Int32
+	len   atomic.Int64
+	seed  atomic.Uint64
+
+	// src is Options.Source, a Source is not safe for concurrent use.
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+// ConcurrentEmbedder should be embedded in the element struct of
+// ConcurrentListO.
+type ConcurrentEmbedder[E any] struct {
+	tower []atomic.Pointer[clink[E]]
+}
+
+func (e *ConcurrentEmbedder[E]) cTower() *[]atomic.Pointer[clink[E]] {
+	return &e.tower
+}
+
+type ConcurrentElementO[K cmp.Ordered, E any] interface {
+	Key() K
+	*E
+
+	cTower() *[]atomic.Pointer[clink[E]]
+}
+
+// clink is a forward pointer with the deletion mark of its owner, a nil link
+// means no successor and no mark.
+type clink[E any] struct {
+	next   *E
+	marked bool
+}
+
+func loadLink[E any](p *atomic.Pointer[clink[E]]) (next *E, marked bool) {
+	if l := p.Load(); l != nil {
+		return l.next, l.marked
+	}
+	return nil, false
+}
+
+// casLink swaps the link at p for n when it still is (next, marked). A link is
+// immutable, so n may be shared by several slots.
+func casLink[E any](p *atomic.Pointer[clink[E]], next *E, marked bool, n *clink[E]) bool {
+	old := p.Load()
+	if old == nil {
+		if next != nil || marked {
+			return false
+		}
+	} else if old.next != next || old.marked != marked {
+		return false
+	}
+	return p.CompareAndSwap(old, n)
+}
+
+type cpath[E any] struct {
+	preds [MaximumLevel]*atomic.Pointer[clink[E]]
+	succs [MaximumLevel]*E
+}
+
+// NewConcurrentO creates a new lock-free skiplist.
+//
+// The zero value of ConcurrentListO is also an empty skiplist ready to use.
+func NewConcurrentO[K cmp.Ordered, E any, PE ConcurrentElementO[K, E]]() *ConcurrentListO[K, E, PE] {
+	l := &ConcurrentListO[K, E, PE]{}
+	l.seed.Store(uint64(newSeed()))
+	return l
+}
+
+// NewConcurrentOWith creates a new lock-free skiplist with options, only
+// Source is supported. The levels are drawn from it under a mutex, since a
+// Source is not safe for concurrent use.
+func NewConcurrentOWith[K cmp.Ordered, E any, PE ConcurrentElementO[K, E]](opts Options) *ConcurrentListO[K, E, PE] {
+	if opts.Ranked || opts.Duplicates || opts.Probability != 0 || opts.LevelLimit != nil || opts.NoShrink || opts.Pool != nil {
+		panic("only Source is supported")
+	}
+	l := NewConcurrentO[K, E, PE]()
+	l.src = opts.Source
+	return l
+}
+
+// Len returns number of elements in the skiplist.
+func (l *ConcurrentListO[K, E, PE]) Len() int {
+	return int(l.len.Load())
+}
+
+// Get searches for the specified element, returns nil when not found.
+func (l *ConcurrentListO[K, E, PE]) Get(k K) *E {
+	e := l.seek(k)
+	if e == nil || PE(e).Key() != k {
+		return nil
+	}
+	return e
+}
+
+// Insert inserts a new element, returns false and does nothing when found.
+func (l *ConcurrentListO[K, E, PE]) Insert(e *E) bool {
+	k := PE(e).Key()
+
+	lev := l.randLevel()
+	l.raise(lev)
+
+	// the link from each predecessor to e
+	link := &clink[E]{next: e}
+
+	// The tower is assigned once k is known to be absent, so e is not linked
+	// and no reader can see it yet. A linked e keeps its live tower.
+	var tower []atomic.Pointer[clink[E]]
+	path := &cpath[E]{}
+	for {
+		if l.find(k, path) {
+			return false
+		}
+		if tower == nil {
+			tower = make([]atomic.Pointer[clink[E]], lev)
+			*PE(e).cTower() = tower
+		}
+		for i := 0; i < lev; i++ {
+			if old := tower[i].Load(); old == nil || old.next != path.succs[i] {
+				tower[i].Store(&clink[E]{next: path.succs[i]})
+			}
+		}
+		if casLink(path.preds[0], path.succs[0], false, link) {
+			break
+		}
+	}
+	l.len.Add(1)
+
+	for i := 1; i < lev; i++ {
+		for {
+			old := tower[i].Load()
+			if old.marked {
+				// Deleted concurrently, stop linking.
+				return true
+			}
+			succ := path.succs[i]
+			if old.next != succ && !tower[i].CompareAndSwap(old, &clink[E]{next: succ}) {
+				continue
+			}
+			if casLink(path.preds[i], succ, false, link) {
+				break
+			}
+			l.find(k, path)
+		}
+	}
+
+	return true
+}
+
+// Delete removes the specified element from the skiplist and returns it,
+// returns nil when not found.
+func (l *ConcurrentListO[K, E, PE]) Delete(k K) *E {
+	path := &cpath[E]{}
+	if !l.find(k, path) {
+		return nil
+	}
+
+	e := path.succs[0]
+	tower := *PE(e).cTower()
+	for i := len(tower) - 1; i > 0; i-- {
+		for {
+			old := tower[i].Load()
+			if old.marked || tower[i].CompareAndSwap(old, &clink[E]{old.next, true}) {
+				break
+			}
+		}
+	}
+
+	for {
+		old := tower[0].Load()
+		if old.marked {
+			// Deleted by another goroutine.
+			return nil
+		}
+		if tower[0].CompareAndSwap(old, &clink[E]{old.next, true}) {
+			l.len.Add(-1)
+			// Unlink it physically.
+			l.find(k, path)
+			return e
+		}
+	}
+}
+
+// Iterate will call iterator once for each element in ascending order.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *ConcurrentListO[K, E, PE]) Iterate(iterator Iterator[E]) {
+	cur, _ := loadLink(&l.head[0])
+	l.iterate(cur, iterator)
+}
+
+// IterateFrom will call iterator once for each element greater or equal than
+// pivot in ascending order.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *ConcurrentListO[K, E, PE]) IterateFrom(pivot K, iterator Iterator[E]) {
+	l.iterate(l.seek(pivot), iterator)
+}
+
+func (l *ConcurrentListO[K, E, PE]) iterate(cur *E, iterator Iterator[E]) {
+	for cur != nil {
+		next, marked := loadLink(&(*PE(cur).cTower())[0])
+		if !marked && !iterator(cur) {
+			return
+		}
+		cur = next
+	}
+}
+
+// seek returns the first element greater or equal than k without helping
+// to unlink the deleted elements.
+func (l *ConcurrentListO[K, E, PE]) seek(k K) *E {
+	prev := l.head[:]
+	var cur *E
+	for i := int(l.level.Load()) - 1; i >= 0; i-- {
+		cur, _ = loadLink(&prev[i])
+		for cur != nil {
+			tower := *PE(cur).cTower()
+			next, marked := loadLink(&tower[i])
+			if marked {
+				cur = next
+				continue
+			}
+			if PE(cur).Key() >= k {
+				break
+			}
+			prev, cur = tower, next
+		}
+	}
+	return cur
+}
+
+// find fills path for k, unlinking the marked elements on the way. It
+// reports whether an element equal to k is found at path.succs[0].
+func (l *ConcurrentListO[K, E, PE]) find(k K, path *cpath[E]) bool {
+	top := int(l.level.Load())
+	for i := top; i < MaximumLevel; i++ {
+		path.preds[i] = &l.head[i]
+		path.succs[i] = nil
+	}
+
+retry:
+	for {
+		prev := l.head[:]
+		for i := top - 1; i >= 0; i-- {
+			cur, _ := loadLink(&prev[i])
+			for cur != nil {
+				tower := *PE(cur).cTower()
+				next, marked := loadLink(&tower[i])
+				if marked {
+					if !casLink(&prev[i], cur, false, &clink[E]{next: next}) {
+						continue retry
+					}
+					cur = next
+					continue
+				}
+				if PE(cur).Key() >= k {
+					break
+				}
+				prev, cur = tower, next
+			}
+			path.preds[i] = &prev[i]
+			path.succs[i] = cur
+		}
+
+		e := path.succs[0]
+		return e != nil && PE(e).Key() == k
+	}
+}
+
+// raise makes sure the search starts at or above lev.
+func (l *ConcurrentListO[K, E, PE]) raise(lev int) {
+	for {
+		cur := l.level.Load()
+		if int(cur) >= lev || l.level.CompareAndSwap(cur, int32(lev)) {
+			return
+		}
+	}
+}
+
+func (l *ConcurrentListO[K, E, PE]) randLevel() int {
+	var z uint64
+	if l.src != nil {
+		l.mu.Lock()
+		z = l.src.Uint64()
+		l.mu.Unlock()
+	} else {
+		rnd := splitMix64(l.seed.Add(1))
+		z = rnd.Uint64()
+	}
+
+	// Two random bits per level, see PROPABILITY.
+	lev := 1
+	for z&3 == 0 && lev < MaximumLevel {
+		lev++
+		z >>= 2
+	}
+	return lev
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+type citem struct {
+	uskiplist.ConcurrentEmbedder[citem]
+
+	k int
+}
+
+func (i *citem) Key() int {
+	return i.k
+}
+
+func checkConcurrentList(t *testing.T, l *uskiplist.ConcurrentListO[int, citem, *citem], want map[int]bool) {
+	t.Helper()
+
+	n, last := 0, -1
+	l.Iterate(func(e *citem) bool {
+		if e.k <= last {
+			t.Fatalf("Iterate visited %d after %d", e.k, last)
+		}
+		if !want[e.k] {
+			t.Fatalf("Iterate visited unexpected %d", e.k)
+		}
+		last = e.k
+		n++
+		return true
+	})
+	if n != len(want) || l.Len() != len(want) {
+		t.Fatalf("Iterate visited %d, Len = %d, want %d", n, l.Len(), len(want))
+	}
+}
+
+func TestConcurrentListO(t *testing.T) {
+	l := uskiplist.NewConcurrentO[int, citem]()
+
+	// Each writer owns the keys k with k%writers == w.
+	const writers = 32
+	const keys = 20000
+
+	var wg sync.WaitGroup
+	owned := make([]map[int]bool, writers)
+	for w := 0; w < writers; w++ {
+		owned[w] = make(map[int]bool)
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < 2000; i++ {
+				k := r.Intn(keys/writers)*writers + w
+				if owned[w][k] {
+					if r.Intn(2) == 0 {
+						if e := l.Delete(k); e == nil || e.k != k {
+							t.Errorf("Delete(%d) = %v", k, e)
+						}
+						delete(owned[w], k)
+					} else if l.Insert(&citem{k: k}) {
+						t.Errorf("Insert existing %d succeeded", k)
+					}
+				} else {
+					if !l.Insert(&citem{k: k}) {
+						t.Errorf("Insert(%d) failed", k)
+					}
+					owned[w][k] = true
+				}
+				if e := l.Get(k); (e != nil) != owned[w][k] {
+					t.Errorf("Get(%d) = %v, want present %v", k, e, owned[w][k])
+				}
+			}
+		}(w)
+	}
+
+	// Readers run alongside, only checking the order.
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		readers.Add(1)
+		go func(r int) {
+			defer readers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				last := -1
+				l.IterateFrom(r*keys/4, func(e *citem) bool {
+					if e.k <= last {
+						t.Errorf("IterateFrom visited %d after %d", e.k, last)
+					}
+					last = e.k
+					return true
+				})
+			}
+		}(r)
+	}
+
+	wg.Wait()
+	close(stop)
+	readers.Wait()
+
+	all := make(map[int]bool)
+	for _, m := range owned {
+		for k := range m {
+			all[k] = true
+		}
+	}
+	checkConcurrentList(t, l, all)
+}
+
+func TestConcurrentListOContended(t *testing.T) {
+	var l uskiplist.ConcurrentListO[int, citem, *citem]
+
+	var wg sync.WaitGroup
+	for w := 0; w < 16; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < 3000; i++ {
+				k := r.Intn(64)
+				if r.Intn(2) == 0 {
+					l.Insert(&citem{k: k})
+				} else {
+					l.Delete(k)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	want := make(map[int]bool)
+	for k := 0; k < 64; k++ {
+		if l.Get(k) != nil {
+			want[k] = true
+		}
+	}
+	checkConcurrentList(t, &l, want)
+}
+
+// countSource counts the levels drawn from it, it is not safe for concurrent
+// use, so the race detector checks that the list serializes the calls.
+type countSource struct {
+	rand.Source64
+	n int
+}
+
+func (s *countSource) Uint64() uint64 {
+	s.n++
+	return s.Source64.Uint64()
+}
+
+func (s *countSource) Int63() int64 {
+	s.n++
+	return s.Source64.Int63()
+}
+
+func TestConcurrentListOSource(t *testing.T) {
+	src := &countSource{Source64: uskiplist.NewSource(1)}
+	l := uskiplist.NewConcurrentOWith[int, citem](uskiplist.Options{Source: src})
+
+	const writers = 8
+	const keys = 4000
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for k := w; k < keys; k += writers {
+				l.Insert(&citem{k: k})
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if src.n != keys {
+		t.Fatalf("Source drew %d levels for %d inserts", src.n, keys)
+	}
+	want := make(map[int]bool)
+	for k := 0; k < keys; k++ {
+		want[k] = true
+	}
+	checkConcurrentList(t, l, want)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewConcurrentOWith accepted Ranked")
+		}
+	}()
+	uskiplist.NewConcurrentOWith[int, citem](uskiplist.Options{Ranked: true})
+}
+
+func TestConcurrentListOInsertLinked(t *testing.T) {
+	l := uskiplist.NewConcurrentO[int, citem]()
+	items := make([]citem, 100)
+	want := make(map[int]bool)
+	for i := range items {
+		items[i].k = i
+		l.Insert(&items[i])
+		want[i] = true
+	}
+
+	for _, i := range []int{0, 10, 50, 99} {
+		if l.Insert(&items[i]) {
+			t.Fatalf("Insert of linked element %d returned true", i)
+		}
+	}
+	checkConcurrentList(t, l, want)
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist
+
+// cursorElement is the part of Element, ElementO and ElementF used by Cursor.
+type cursorElement[K, E any] interface {
+	linker[E]
+	Key() K
+}
+
+// searcher is the key dependent part of List, ListO and ListF used by Cursor.
+type searcher[K, E any] interface {
+	search(k K, lev int, path *searchPath[E]) *E
+	searchUpper(k K, lev int, path *searchPath[E])
+}
+
+// Cursor is a positional iterator over a skiplist in ascending order, it can
+// be paused at any time and resumed later, or moved in lockstep with another
+// one.
+//
+// A Cursor steps from element to element in O(1) while the skiplist is not
+// modified. After any modification, Next searches again for the first element
+// greater than the key of the current element, which takes O(log n). So the
+// current element may be deleted: Elem keeps returning it until Next moves to
+// its successor in the modified skiplist. Elements with the same key as the
+// current one are skipped in this case when duplicates are allowed.
+type Cursor[K any, E any, PE cursorElement[K, E]] struct {
+	l    *listBase[E, PE]
+	list searcher[K, E]
+
+	e *E
+	// relay is the first element with more than one level starting from e.
+	relay *E
+	mods  uint
+}
+
+func newCursor[K any, E any, PE cursorElement[K, E]](l *listBase[E, PE], list searcher[K, E]) *Cursor[K, E, PE] {
+	c := &Cursor[K, E, PE]{l: l, list: list}
+	c.SeekFirst()
+	return c
+}
+
+// SeekFirst moves the cursor to the first element.
+func (c *Cursor[K, E, PE]) SeekFirst() {
+	c.e, c.relay = c.l.root[0], c.l.root[1]
+	c.mods = c.l.mods
+}
+
+// Seek moves the cursor to the first element greater than or equal to k.
+func (c *Cursor[K, E, PE]) Seek(k K) {
+	path := &searchPath[E]{}
+	c.list.search(k, c.l.idealLevel(), path)
+	c.e, c.relay = *path[0], *path[1]
+	c.mods = c.l.mods
+}
+
+// Valid tells whether the cursor is at an element.
+func (c *Cursor[K, E, PE]) Valid() bool {
+	return c.e != nil
+}
+
+// Elem returns the current element, returns nil when the cursor is not valid.
+func (c *Cursor[K, E, PE]) Elem() *E {
+	return c.e
+}
+
+// Next moves the cursor to the next element, the cursor becomes invalid after
+// the last element.
+func (c *Cursor[K, E, PE]) Next() {
+	if c.e == nil {
+		return
+	}
+
+	if c.mods != c.l.mods {
+		path := &searchPath[E]{}
+		c.list.searchUpper(PE(c.e).Key(), c.l.idealLevel(), path)
+		c.e, c.relay = *path[0], *path[1]
+		c.mods = c.l.mods
+		return
+	}
+
+	if c.e == c.relay {
+		ln := PE(c.e).lnNext()
+		c.e, c.relay = ln[0], ln[1]
+		return
+	}
+	c.e = PE(c.e).l1Next()[0]
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist
+
+// nearSearcher is the key dependent part of List, ListO and ListF used by
+// Finger.
+type nearSearcher[K, E any] interface {
+	searcher[K, E]
+	searchNear(k K, upper bool, path *searchPath[E], from K, fromUpper bool) *E
+}
+
+// Finger is a search finger over a skiplist, it keeps the search path of its
+// last operation. An operation on a key d elements after the previous one
+// takes O(log d) instead of O(log n), so near sequential workloads, like
+// time series ingestion, avoid starting each search from the root.
+//
+// A key before the previous one costs a full search, so does the first
+// operation after the skiplist is modified by other means.
+type Finger[K any, E any, PE cursorElement[K, E]] struct {
+	l    *listBase[E, PE]
+	list nearSearcher[K, E]
+
+	path  searchPath[E]
+	key   K
+	upper bool
+	mods  uint
+}
+
+func newFinger[K any, E any, PE cursorElement[K, E]](l *listBase[E, PE], list nearSearcher[K, E]) *Finger[K, E, PE] {
+	return &Finger[K, E, PE]{l: l, list: list}
+}
+
+// find fills the path for k, see search and searchUpper.
+func (f *Finger[K, E, PE]) find(k K, upper bool) (e *E) {
+	switch {
+	case f.path[0] != nil && f.mods == f.l.mods:
+		e = f.list.searchNear(k, upper, &f.path, f.key, f.upper)
+	case upper:
+		f.list.searchUpper(k, f.l.maxL, &f.path)
+	default:
+		e = f.list.search(k, f.l.maxL, &f.path)
+	}
+	f.key, f.upper = k, upper
+	f.mods = f.l.mods
+	return e
+}
+
+// Get searches for the specified element, returns nil when not found.
+func (f *Finger[K, E, PE]) Get(k K) *E {
+	return f.find(k, false)
+}
+
+// Insert inserts a new element, do nothing when found. When duplicates are
+// allowed, it is inserted after the elements with equal key.
+func (f *Finger[K, E, PE]) Insert(e *E) {
+	f.InsertOrGet(e)
+}
+
+// InsertOrGet inserts a new element and returns true, or returns the element
+// found and false. When duplicates are allowed, it is always inserted after
+// the elements with equal key.
+func (f *Finger[K, E, PE]) InsertOrGet(e *E) (existing *E, inserted bool) {
+	l := f.l
+	if l.opts.Duplicates {
+		f.find(PE(e).Key(), true)
+	} else if existing = f.find(PE(e).Key(), false); existing != nil {
+		return existing, false
+	}
+
+	// The path stays filled for the key, its slots now lead to e.
+	root, maxL := l.root, l.maxL
+	l.link(e, &f.path)
+	l.repath(&f.path, root, maxL)
+	f.mods = l.mods
+	return nil, true
+}
+
+// Remove removes the element from the skiplist and returns it, returns nil
+// when not found.
+func (f *Finger[K, E, PE]) Remove(k K) *E {
+	e := f.find(k, false)
+	if e == nil {
+		return nil
+	}
+
+	f.l.unlink(e, &f.path)
+	f.mods = f.l.mods
+	return e
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+func TestFinger(t *testing.T) {
+	for _, ranked := range []bool{false, true} {
+		l := uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: ranked})
+		f := l.Finger()
+
+		present := make(map[int]*item2)
+		k := 0
+		for i := 0; i < 50000; i++ {
+			// mostly forward, sometimes backward or far away
+			switch r := rand.Intn(100); {
+			case r < 5:
+				k -= rand.Intn(50)
+			case r < 7:
+				k = rand.Intn(20000)
+			default:
+				k += rand.Intn(4)
+			}
+
+			switch r := rand.Intn(10); {
+			case r < 5:
+				e := &item2{k: k}
+				existing, inserted := f.InsertOrGet(e)
+				if existing != present[k] || inserted != (present[k] == nil) {
+					t.Fatalf("ranked=%v InsertOrGet(%d) = %p, %v", ranked, k, existing, inserted)
+				}
+				if inserted {
+					present[k] = e
+				}
+			case r < 7:
+				if e := f.Remove(k); e != present[k] {
+					t.Fatalf("ranked=%v Remove(%d) = %p, want %p", ranked, k, e, present[k])
+				}
+				delete(present, k)
+			case r < 9:
+				if e := f.Get(k); e != present[k] {
+					t.Fatalf("ranked=%v Get(%d) = %p, want %p", ranked, k, e, present[k])
+				}
+			default:
+				// modify behind the finger
+				if e := l.Remove(k + 1); e != present[k+1] {
+					t.Fatalf("ranked=%v Remove(%d) = %p, want %p", ranked, k+1, e, present[k+1])
+				}
+				delete(present, k+1)
+			}
+		}
+
+		var keys []int
+		for k := range present {
+			keys = append(keys, k)
+		}
+		sort.Ints(keys)
+		if got := collect(l.Iterate); !reflect.DeepEqual(got, keys) {
+			t.Fatalf("ranked=%v Iterate = %v, want %v", ranked, got, keys)
+		}
+		if ranked {
+			for i, k := range keys {
+				if e := l.At(i); e != present[k] {
+					t.Fatalf("At(%d) = %v, want %d", i, e, k)
+				}
+			}
+		}
+	}
+}
+
+func TestFingerDuplicates(t *testing.T) {
+	l := uskiplist.NewOWith[int, dupItem](uskiplist.Options{Duplicates: true})
+	f := l.Finger()
+
+	var want []dupItem
+	for seq := 0; seq < 3000; seq++ {
+		e := &dupItem{k: seq / 3 * 2, seq: seq}
+		if seq%10 == 9 {
+			e.k -= 7
+		}
+		f.Insert(e)
+		want = append(want, dupItem{k: e.k, seq: e.seq})
+	}
+	sort.SliceStable(want, func(i, j int) bool { return want[i].k < want[j].k })
+
+	var got []dupItem
+	l.Iterate(func(e *dupItem) bool {
+		got = append(got, dupItem{k: e.k, seq: e.seq})
+		return true
+	})
+	if len(got) != len(want) {
+		t.Fatalf("Iterate visited %d elements, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].k != want[i].k || got[i].seq != want[i].seq {
+			t.Fatalf("element %d = %d/%d, want %d/%d", i, got[i].k, got[i].seq, want[i].k, want[i].seq)
+		}
+	}
+}
+
+// nearKeys returns n ascending keys with small gaps, shuffled locally so a
+// few of them are before the previous one.
+func nearKeys(n int) []int {
+	r := rand.New(rand.NewSource(1))
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = i*4 + r.Intn(8)
+	}
+	return keys
+}
+
+func BenchmarkInsertNear(b *testing.B) {
+	keys := nearKeys(b.N)
+	elems := make([]item2, b.N)
+	l := uskiplist.NewO[int, item2]()
+	b.ResetTimer()
+	for i, k := range keys {
+		elems[i].k = k
+		l.Insert(&elems[i])
+	}
+}
+
+func BenchmarkFingerInsertNear(b *testing.B) {
+	keys := nearKeys(b.N)
+	elems := make([]item2, b.N)
+	f := uskiplist.NewO[int, item2]().Finger()
+	b.ResetTimer()
+	for i, k := range keys {
+		elems[i].k = k
+		f.Insert(&elems[i])
+	}
+}
+
+func benchmarkGetNear(b *testing.B, get func(l *testList) func(int) *item2) {
+	const n = 1 << 20
+	keys := nearKeys(n)
+	elems := make([]item2, n)
+	l := uskiplist.NewO[int, item2]()
+	f := l.Finger()
+	for i, k := range keys {
+		elems[i].k = k
+		f.Insert(&elems[i])
+	}
+	fn := get(l)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fn(keys[i%n])
+	}
+}
+
+func BenchmarkGetNear(b *testing.B) {
+	benchmarkGetNear(b, func(l *testList) func(int) *item2 { return l.Get })
+}
+
+func BenchmarkFingerGetNear(b *testing.B) {
+	benchmarkGetNear(b, func(l *testList) func(int) *item2 { return l.Finger().Get })
+}
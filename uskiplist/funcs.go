@@ -0,0 +1,506 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist
+
+import "unsafe"
+
+type ElementF[K any, E any] interface {
+	Key() K
+	*E
+
+	ptNext() *unsafe.Pointer
+	l1Next() *level1[E]
+	lnNext() *leveln[E]
+}
+
+// ListF is a skiplist whose keys are ordered by a less function supplied at
+// construction time, less must be a strict weak ordering.
+//
+// Unlike List and ListO, the zero value of ListF is not usable until InitFunc
+// is called.
+type ListF[K any, E any, PE ElementF[K, E]] struct {
+	listBase[E, PE]
+	less func(a, b K) bool
+}
+
+// NewF creates and initializes a new skiplist, ordering the keys with less.
+func NewF[K any, E any, PE ElementF[K, E]](less func(a, b K) bool) *ListF[K, E, PE] {
+	l := &ListF[K, E, PE]{less: less}
+	l.Init()
+	return l
+}
+
+// NewFWith creates and initializes a new skiplist with options, ordering the
+// keys with less.
+func NewFWith[K any, E any, PE ElementF[K, E]](less func(a, b K) bool, opts Options) *ListF[K, E, PE] {
+	l := &ListF[K, E, PE]{less: less}
+	l.InitWith(opts)
+	return l
+}
+
+// Init initializes the skiplist, keeping the options and the less function.
+func (l *ListF[K, E, PE]) Init() {
+	l.init()
+}
+
+// InitWith initializes the skiplist with options, keeping the less function.
+func (l *ListF[K, E, PE]) InitWith(opts Options) {
+	l.opts = opts
+	l.init()
+}
+
+// InitFunc initializes the skiplist with the less function and options.
+func (l *ListF[K, E, PE]) InitFunc(less func(a, b K) bool, opts Options) {
+	l.less = less
+	l.opts = opts
+	l.init()
+}
+
+// Len returns number of elements in the skiplist.
+func (l *ListF[K, E, PE]) Len() int { return l.len }
+
+// Less returns the less function of the skiplist.
+func (l *ListF[K, E, PE]) Less() func(a, b K) bool { return l.less }
+
+// Get searches for the specified element, returns nil when not found.
+func (l *ListF[K, E, PE]) Get(k K) *E {
+	return l.search(k, l.idealLevel(), nil)
+}
+
+// IndexOf returns the index (0-based) of the specified element, returns -1
+// when not found. It takes O(log n) in a ranked skiplist, and O(n) otherwise.
+func (l *ListF[K, E, PE]) IndexOf(k K) int {
+	path := &searchPath[E]{}
+	e := l.search(k, l.maxL, path)
+	return l.indexOf(e, path)
+}
+
+// Insert inserts a new element, do nothing when found. When duplicates are
+// allowed, it is inserted after the elements with equal key.
+func (l *ListF[K, E, PE]) Insert(e *E) {
+	l.InsertOrGet(e)
+}
+
+// InsertOrGet inserts a new element and returns true, or returns the element
+// found and false. When duplicates are allowed, it is always inserted after
+// the elements with equal key.
+func (l *ListF[K, E, PE]) InsertOrGet(e *E) (existing *E, inserted bool) {
+	path := &searchPath[E]{}
+	lev := l.maxL
+
+	if l.opts.Duplicates {
+		l.searchUpper(PE(e).Key(), lev, path)
+	} else if existing = l.search(PE(e).Key(), lev, path); existing != nil {
+		return existing, false
+	}
+
+	l.link(e, path)
+	return nil, true
+}
+
+// Replace puts a new element in place of the element found and returns the
+// old one, which is unlinked. It inserts the new element and returns nil when
+// not found. When duplicates are allowed, the first one is replaced. It does
+// nothing and returns e when e itself is found.
+func (l *ListF[K, E, PE]) Replace(e *E) (old *E) {
+	path := &searchPath[E]{}
+	lev := l.maxL
+
+	old = l.search(PE(e).Key(), lev, path)
+	switch old {
+	case nil:
+		l.link(e, path)
+	case e:
+	default:
+		l.replace(old, e, path)
+	}
+	return old
+}
+
+// Delete remove the element from the skiplist, do nothing when not found.
+func (l *ListF[K, E, PE]) Delete(k K) {
+	l.Remove(k)
+}
+
+// Remove removes the element from the skiplist and returns it, returns nil
+// when not found.
+func (l *ListF[K, E, PE]) Remove(k K) *E {
+	path := &searchPath[E]{}
+	lev := l.maxL
+
+	e := l.search(k, lev, path)
+	if e == nil {
+		return nil
+	}
+
+	l.unlink(e, path)
+	return e
+}
+
+// Compute searches for k once and calls fn with the element found, or nil.
+// fn returns the element to keep at k: the found one to leave it, nil to
+// remove it, or another element with key k to insert it or to replace the
+// found one in place. It returns the element at k afterwards.
+//
+// When duplicates are allowed, the first element with key k is found. fn must
+// not modify the skiplist.
+func (l *ListF[K, E, PE]) Compute(k K, fn func(e *E) *E) *E {
+	path := &searchPath[E]{}
+	e := l.search(k, l.maxL, path)
+
+	n := fn(e)
+	switch {
+	case n == e:
+	case n == nil:
+		l.unlink(e, path)
+	case e == nil:
+		l.link(n, path)
+	default:
+		l.replace(e, n, path)
+	}
+	return n
+}
+
+// DeleteRange removes all elements between lo and hi in one pass, bounds
+// tells whether lo and hi are included. It returns the number of removed
+// elements.
+func (l *ListF[K, E, PE]) DeleteRange(lo, hi K, bounds Bounds) int {
+	path := &searchPath[E]{}
+	l.searchBound(lo, bounds, l.maxL, path)
+
+	n := 0
+	for e := *path[0]; e != nil && l.belowBound(e, hi, bounds); e = *path[0] {
+		l.unlink(e, path)
+		n++
+	}
+	return n
+}
+
+// Cursor returns a new cursor at the first element, see Cursor.
+func (l *ListF[K, E, PE]) Cursor() *Cursor[K, E, PE] {
+	return newCursor[K, E, PE](&l.listBase, l)
+}
+
+// Finger returns a new search finger, see Finger.
+func (l *ListF[K, E, PE]) Finger() *Finger[K, E, PE] {
+	return newFinger[K, E, PE](&l.listBase, l)
+}
+
+// Iterate will call iterator once for each element in ascending order.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *ListF[K, E, PE]) Iterate(iterator Iterator[E]) {
+	var cur, relay *E
+
+	if l.root[0] != l.root[1] {
+		cur = l.root[0]
+	}
+	relay = l.root[1]
+
+	l.iterate(cur, relay, iterator)
+}
+
+// IterateFrom will call iterator once for each element greater or equal than
+// pivot in ascending order.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *ListF[K, E, PE]) IterateFrom(pivot K, iterator Iterator[E]) {
+	var cur *E
+
+	path := &searchPath[E]{}
+	l.search(pivot, l.idealLevel(), path)
+	if *path[0] != *path[1] {
+		cur = *path[0]
+	}
+
+	l.iterate(cur, *path[1], iterator)
+}
+
+// IterateAfter will call iterator once for each element greater than pivot
+// in ascending order.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *ListF[K, E, PE]) IterateAfter(pivot K, iterator Iterator[E]) {
+	var cur *E
+
+	path := &searchPath[E]{}
+	l.searchUpper(pivot, l.idealLevel(), path)
+	if *path[0] != *path[1] {
+		cur = *path[0]
+	}
+
+	l.iterate(cur, *path[1], iterator)
+}
+
+// IterateBetween will call iterator once for each element between lo and hi
+// in ascending order, bounds tells whether lo and hi are included.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *ListF[K, E, PE]) IterateBetween(lo, hi K, bounds Bounds, iterator Iterator[E]) {
+	var cur *E
+
+	path := &searchPath[E]{}
+	l.searchBound(lo, bounds, l.idealLevel(), path)
+	if *path[0] != *path[1] {
+		cur = *path[0]
+	}
+
+	l.iterate(cur, *path[1], func(e *E) bool {
+		return l.belowBound(e, hi, bounds) && iterator(e)
+	})
+}
+
+// IterateBefore will call iterator once for each element less than pivot in
+// descending order.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *ListF[K, E, PE]) IterateBefore(pivot K, iterator Iterator[E]) {
+	stack := l.revStack()
+	for i := l.maxL - 1; i >= 0; i-- {
+		cur := l.next(stack[len(stack)-1].e, i)
+		for cur != nil && l.less(PE(cur).Key(), pivot) {
+			stack = append(stack, revFrame[E]{cur, i})
+			if i > 0 {
+				cur = PE(cur).lnNext()[i]
+			} else {
+				cur = PE(cur).l1Next()[0]
+			}
+		}
+	}
+	l.reverse(stack, iterator)
+}
+
+// Floor returns the last element less than or equal to k, returns nil when
+// not found.
+func (l *ListF[K, E, PE]) Floor(k K) *E {
+	return l.before(k, true)
+}
+
+// Ceiling returns the first element greater than or equal to k, returns nil
+// when not found.
+func (l *ListF[K, E, PE]) Ceiling(k K) *E {
+	path := &searchPath[E]{}
+	l.search(k, l.idealLevel(), path)
+	return *path[0]
+}
+
+// Lower returns the last element less than k, returns nil when not found.
+func (l *ListF[K, E, PE]) Lower(k K) *E {
+	return l.before(k, false)
+}
+
+// Higher returns the first element greater than k, returns nil when not
+// found.
+func (l *ListF[K, E, PE]) Higher(k K) *E {
+	path := &searchPath[E]{}
+	l.searchUpper(k, l.idealLevel(), path)
+	return *path[0]
+}
+
+// Sample samples about one for every step elements.
+func (l *ListF[K, E, PE]) Sample(step int, iterator Iterator[E]) {
+	if l.len == 0 {
+		return
+	}
+	if step >= l.len {
+		iterator(l.root[0])
+		return
+	}
+
+	i := l.sampleLevel(step) - 1
+
+	if l.root[0] != l.root[i] {
+		if !iterator(l.root[0]) {
+			return
+		}
+	}
+
+	cur := l.root[i]
+	for cur != nil {
+		if !iterator(cur) {
+			return
+		}
+
+		ln := PE(cur).lnNext()
+		cur = ln[i]
+	}
+}
+
+// lev : [2, l.maxL]
+func (l *ListF[K, E, PE]) search(k K, lev int, path *searchPath[E]) (e *E) {
+	if lev < 2 {
+		lev = 2
+	}
+	if lev > l.maxL {
+		lev = l.maxL
+	}
+
+	pre := l.root
+	for i := lev - 1; i > 0; i-- {
+		for pre[i] != nil && l.less(PE(pre[i]).Key(), k) {
+			pre = PE(pre[i]).lnNext()
+		}
+		if path != nil {
+			path[i] = &pre[i]
+		}
+	}
+
+	var preL0 *level1[E]
+	if pre[0] != nil && l.less(PE(pre[0]).Key(), k) {
+		preL0 = PE(pre[0]).l1Next()
+		for preL0[0] != nil && l.less(PE(preL0[0]).Key(), k) {
+			preL0 = PE(preL0[0]).l1Next()
+		}
+	}
+
+	if preL0 != nil {
+		if preL0[0] != nil && !l.less(k, PE(preL0[0]).Key()) {
+			e = preL0[0]
+		}
+		if path != nil {
+			path[0] = &preL0[0]
+		}
+	} else {
+		if pre[0] != nil && !l.less(k, PE(pre[0]).Key()) {
+			e = pre[0]
+		}
+		if path != nil {
+			path[0] = &pre[0]
+		}
+	}
+
+	return
+}
+
+// before returns the last element less than k, or less than or equal to k
+// when orEqual.
+func (l *ListF[K, E, PE]) before(k K, orEqual bool) *E {
+	var last *E
+
+	precedes := func(e *E) bool {
+		if orEqual {
+			return !l.less(k, PE(e).Key())
+		}
+		return l.less(PE(e).Key(), k)
+	}
+
+	ln := l.root
+	for i := l.maxL - 1; i > 0; i-- {
+		for ln[i] != nil && precedes(ln[i]) {
+			last = ln[i]
+			ln = PE(last).lnNext()
+		}
+	}
+
+	cur := ln[0]
+	for cur != nil && precedes(cur) {
+		last = cur
+		cur = PE(cur).l1Next()[0]
+	}
+
+	return last
+}
+
+// searchUpper is like search, but path is filled for the first element
+// greater than k.
+//
+// lev : [2, l.maxL]
+func (l *ListF[K, E, PE]) searchUpper(k K, lev int, path *searchPath[E]) {
+	if lev < 2 {
+		lev = 2
+	}
+	if lev > l.maxL {
+		lev = l.maxL
+	}
+
+	pre := l.root
+	for i := lev - 1; i > 0; i-- {
+		for pre[i] != nil && !l.less(k, PE(pre[i]).Key()) {
+			pre = PE(pre[i]).lnNext()
+		}
+		path[i] = &pre[i]
+	}
+
+	path[0] = &pre[0]
+	if pre[0] != nil && !l.less(k, PE(pre[0]).Key()) {
+		preL0 := PE(pre[0]).l1Next()
+		for preL0[0] != nil && !l.less(k, PE(preL0[0]).Key()) {
+			preL0 = PE(preL0[0]).l1Next()
+		}
+		path[0] = &preL0[0]
+	}
+}
+
+// searchNear is like search, or searchUpper when upper, but starts from path,
+// which must be filled for the key from (by searchUpper when fromUpper). It
+// takes O(log d) when k is d elements after from, and falls back to a full
+// search when k is before from.
+func (l *ListF[K, E, PE]) searchNear(k K, upper bool, path *searchPath[E], from K, fromUpper bool) (e *E) {
+	if l.less(k, from) || fromUpper && !upper && !l.less(from, k) {
+		if upper {
+			l.searchUpper(k, l.maxL, path)
+			return nil
+		}
+		return l.search(k, l.maxL, path)
+	}
+
+	before := func(e *E) bool {
+		if upper {
+			return !l.less(k, PE(e).Key())
+		}
+		return l.less(PE(e).Key(), k)
+	}
+
+	// climb to the lowest level whose successor is not before k, the levels
+	// above it are still filled for k.
+	i := 1
+	for i < l.maxL-1 && *path[i] != nil && before(*path[i]) {
+		i++
+	}
+
+	pre := pathArray(path, i)
+	for ; i > 0; i-- {
+		for pre[i] != nil && before(pre[i]) {
+			pre = PE(pre[i]).lnNext()
+		}
+		path[i] = &pre[i]
+	}
+
+	path[0] = &pre[0]
+	if pre[0] != nil && before(pre[0]) {
+		preL0 := PE(pre[0]).l1Next()
+		for preL0[0] != nil && before(preL0[0]) {
+			preL0 = PE(preL0[0]).l1Next()
+		}
+		path[0] = &preL0[0]
+	}
+
+	if e = *path[0]; e != nil && (upper || l.less(k, PE(e).Key())) {
+		e = nil
+	}
+	return e
+}
+
+// searchBound fills path for the first element above the lower bound lo.
+func (l *ListF[K, E, PE]) searchBound(lo K, bounds Bounds, lev int, path *searchPath[E]) {
+	if bounds&IncludeLo != 0 {
+		l.search(lo, lev, path)
+	} else {
+		l.searchUpper(lo, lev, path)
+	}
+}
+
+// belowBound tells whether e is below the upper bound hi.
+func (l *ListF[K, E, PE]) belowBound(e *E, hi K, bounds Bounds) bool {
+	if bounds&IncludeHi != 0 {
+		return !l.less(hi, PE(e).Key())
+	}
+	return l.less(PE(e).Key(), hi)
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+type itemF struct {
+	uskiplist.Embedder[itemF]
+	name string
+}
+
+func (i *itemF) Key() string {
+	return i.name
+}
+
+func ExampleListF() {
+	l := uskiplist.NewF[string, itemF](func(a, b string) bool {
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+
+	for _, name := range []string{"bob", "Alice", "carol", "BOB", "dave"} {
+		l.Insert(&itemF{name: name})
+	}
+
+	var names []string
+	l.Iterate(func(e *itemF) bool {
+		names = append(names, e.name)
+		return true
+	})
+	fmt.Println(l.Len(), names)
+	fmt.Println(l.Get("ALICE").name, l.Ceiling("BZ").name)
+
+	// Output:
+	// 4 [Alice bob carol dave]
+	// Alice carol
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package uskiplist
+
+import "iter"
+
+// All returns an iterator over the elements in ascending order.
+//
+//	The current element can be deleted during iteration.
+func (l *listBase[E, PE]) All() iter.Seq[*E] {
+	return func(yield func(*E) bool) {
+		var cur, relay *E
+
+		if l.root[0] != l.root[1] {
+			cur = l.root[0]
+		}
+		relay = l.root[1]
+
+		l.iterate(cur, relay, yield)
+	}
+}
+
+// Backward returns an iterator over the elements in descending order.
+//
+//	The current element can be deleted during iteration.
+func (l *listBase[E, PE]) Backward() iter.Seq[*E] {
+	return func(yield func(*E) bool) {
+		l.IterateReverse(yield)
+	}
+}
+
+// From returns an iterator over the elements greater or equal than pivot in
+// ascending order.
+//
+//	The current element can be deleted during iteration.
+func (l *List[K, E, PE]) From(pivot K) iter.Seq[*E] {
+	return func(yield func(*E) bool) {
+		l.IterateFrom(pivot, yield)
+	}
+}
+
+// From returns an iterator over the elements greater or equal than pivot in
+// ascending order.
+//
+//	The current element can be deleted during iteration.
+func (l *ListO[K, E, PE]) From(pivot K) iter.Seq[*E] {
+	return func(yield func(*E) bool) {
+		l.IterateFrom(pivot, yield)
+	}
+}
+
+// From returns an iterator over the elements greater or equal than pivot in
+// ascending order.
+//
+//	The current element can be deleted during iteration.
+func (l *ListF[K, E, PE]) From(pivot K) iter.Seq[*E] {
+	return func(yield func(*E) bool) {
+		l.IterateFrom(pivot, yield)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package uskiplist_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestIterators(t *testing.T) {
+	l, keys := newTestList(t, 1000)
+
+	got := []int{}
+	for e := range l.All() {
+		got = append(got, e.k)
+	}
+	if !reflect.DeepEqual(got, keys) {
+		t.Fatalf("All = %v, want %v", got, keys)
+	}
+
+	got = got[:0]
+	for e := range l.Backward() {
+		got = append(got, e.k)
+		if len(got) == 10 {
+			break
+		}
+	}
+	if want := reversed(keys)[:10]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Backward = %v, want %v", got, want)
+	}
+
+	pivot := keys[500] - 1
+	i := sort.SearchInts(keys, pivot)
+	got = got[:0]
+	for e := range l.From(pivot) {
+		got = append(got, e.k)
+		l.Delete(e.k)
+	}
+	if want := keys[i:]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("From = %v, want %v", got, want)
+	}
+	if l.Len() != i {
+		t.Fatalf("Len after deleting in From = %d, want %d", l.Len(), i)
+	}
+}
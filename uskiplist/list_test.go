@@ -0,0 +1,1112 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist_test
+
+import (
+	"math/bits"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"unsafe"
+
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+type item2 struct {
+	uskiplist.Embedder[item2]
+
+	k int
+}
+
+func (i *item2) Key() int {
+	return i.k
+}
+
+type testList = uskiplist.ListO[int, item2, *item2]
+
+func newTestList(t *testing.T, n int) (*testList, []int) {
+	t.Helper()
+
+	l := uskiplist.NewO[int, item2]()
+	seen := make(map[int]bool)
+	var keys []int
+	for len(keys) < n {
+		k := rand.Intn(n * 10)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		keys = append(keys, k)
+		l.Insert(&item2{k: k})
+	}
+	sort.Ints(keys)
+	return l, keys
+}
+
+func collect(iterate func(uskiplist.Iterator[item2])) []int {
+	keys := []int{}
+	iterate(func(e *item2) bool {
+		keys = append(keys, e.k)
+		return true
+	})
+	return keys
+}
+
+func reversed(keys []int) []int {
+	r := make([]int, len(keys))
+	for i, k := range keys {
+		r[len(keys)-1-i] = k
+	}
+	return r
+}
+
+func TestIterateReverse(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 100, 5000} {
+		l, keys := newTestList(t, n)
+
+		got := collect(l.IterateReverse)
+		if want := reversed(keys); !reflect.DeepEqual(got, want) {
+			t.Fatalf("n=%d IterateReverse = %v, want %v", n, got, want)
+		}
+
+		pivots := []int{-1, 0, n, n * 5, n * 10}
+		if n > 0 {
+			pivots = append(pivots, keys[0], keys[n/2], keys[n-1])
+		}
+		for _, pivot := range pivots {
+			got := collect(func(it uskiplist.Iterator[item2]) {
+				l.IterateBefore(pivot, it)
+			})
+			i := sort.SearchInts(keys, pivot)
+			if want := reversed(keys[:i]); !reflect.DeepEqual(got, want) {
+				t.Fatalf("n=%d IterateBefore(%d) = %v, want %v", n, pivot, got, want)
+			}
+
+			got = collect(func(it uskiplist.Iterator[item2]) {
+				l.IterateAfter(pivot, it)
+			})
+			i = sort.SearchInts(keys, pivot+1)
+			if want := append([]int{}, keys[i:]...); !reflect.DeepEqual(got, want) {
+				t.Fatalf("n=%d IterateAfter(%d) = %v, want %v", n, pivot, got, want)
+			}
+		}
+	}
+}
+
+func TestIterateReverseDeleteCurrent(t *testing.T) {
+	l, keys := newTestList(t, 3000)
+
+	var got []int
+	l.IterateReverse(func(e *item2) bool {
+		got = append(got, e.k)
+		if e.k%2 == 0 {
+			l.Delete(e.k)
+		}
+		return true
+	})
+	if want := reversed(keys); !reflect.DeepEqual(got, want) {
+		t.Fatalf("IterateReverse with deletion visited %v, want %v", got, want)
+	}
+
+	var odd []int
+	for _, k := range keys {
+		if k%2 != 0 {
+			odd = append(odd, k)
+		}
+	}
+	if got := collect(l.Iterate); !reflect.DeepEqual(got, odd) {
+		t.Fatalf("Iterate after deletion = %v, want %v", got, odd)
+	}
+	if l.Len() != len(odd) {
+		t.Fatalf("Len = %d, want %d", l.Len(), len(odd))
+	}
+}
+
+func TestRanked(t *testing.T) {
+	for _, ranked := range []bool{true, false} {
+		l := uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: ranked})
+
+		present := make(map[int]bool)
+		for i := 0; i < 20000; i++ {
+			k := rand.Intn(3000)
+			if rand.Intn(3) == 0 {
+				l.Delete(k)
+				delete(present, k)
+			} else {
+				l.Insert(&item2{k: k})
+				present[k] = true
+			}
+		}
+
+		var keys []int
+		for k := range present {
+			keys = append(keys, k)
+		}
+		sort.Ints(keys)
+
+		if l.Len() != len(keys) {
+			t.Fatalf("ranked=%v Len = %d, want %d", ranked, l.Len(), len(keys))
+		}
+		for i, k := range keys {
+			if e := l.At(i); e == nil || e.k != k {
+				t.Fatalf("ranked=%v At(%d) = %v, want %d", ranked, i, e, k)
+			}
+			if got := l.IndexOf(k); got != i {
+				t.Fatalf("ranked=%v IndexOf(%d) = %d, want %d", ranked, k, got, i)
+			}
+		}
+		if e := l.At(len(keys)); e != nil {
+			t.Fatalf("ranked=%v At(Len) = %v, want nil", ranked, e)
+		}
+		if e := l.At(-1); e != nil {
+			t.Fatalf("ranked=%v At(-1) = %v, want nil", ranked, e)
+		}
+		if got := l.IndexOf(-1); got != -1 {
+			t.Fatalf("ranked=%v IndexOf missing = %d, want -1", ranked, got)
+		}
+
+		for _, i := range []int{0, 1, len(keys) / 2, len(keys) - 1} {
+			got := collect(func(it uskiplist.Iterator[item2]) {
+				l.IterateAt(i, it)
+			})
+			if !reflect.DeepEqual(got, keys[i:]) {
+				t.Fatalf("ranked=%v IterateAt(%d) = %v, want %v", ranked, i, got, keys[i:])
+			}
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	l, keys := newTestList(t, 3000)
+
+	at := func(i int) *int {
+		if i < 0 || i >= len(keys) {
+			return nil
+		}
+		return &keys[i]
+	}
+	check := func(name string, k int, e *item2, want *int) {
+		if (e == nil) != (want == nil) || e != nil && e.k != *want {
+			t.Fatalf("%s(%d) = %v, want %v", name, k, e, want)
+		}
+	}
+
+	for k := -1; k <= 30001; k++ {
+		i := sort.SearchInts(keys, k)
+		found := i < len(keys) && keys[i] == k
+
+		check("Ceiling", k, l.Ceiling(k), at(i))
+		check("Lower", k, l.Lower(k), at(i-1))
+		if found {
+			check("Floor", k, l.Floor(k), at(i))
+			check("Higher", k, l.Higher(k), at(i+1))
+		} else {
+			check("Floor", k, l.Floor(k), at(i-1))
+			check("Higher", k, l.Higher(k), at(i))
+		}
+	}
+
+	check("First", 0, l.First(), at(0))
+	check("Last", 0, l.Last(), at(len(keys)-1))
+}
+
+func TestPop(t *testing.T) {
+	l := uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: true})
+	_, keys := newTestList(t, 2000)
+	for _, k := range keys {
+		l.Insert(&item2{k: k})
+	}
+
+	for len(keys) > 0 {
+		if rand.Intn(2) == 0 {
+			e := l.PopFirst()
+			if e == nil || e.k != keys[0] {
+				t.Fatalf("PopFirst = %v, want %d", e, keys[0])
+			}
+			keys = keys[1:]
+		} else {
+			e := l.PopLast()
+			if e == nil || e.k != keys[len(keys)-1] {
+				t.Fatalf("PopLast = %v, want %d", e, keys[len(keys)-1])
+			}
+			keys = keys[:len(keys)-1]
+		}
+
+		if l.Len() != len(keys) {
+			t.Fatalf("Len = %d, want %d", l.Len(), len(keys))
+		}
+		if len(keys) > 0 {
+			i := len(keys) / 2
+			if got := l.IndexOf(keys[i]); got != i {
+				t.Fatalf("IndexOf(%d) = %d, want %d", keys[i], got, i)
+			}
+		}
+	}
+
+	if l.PopFirst() != nil || l.PopLast() != nil || l.First() != nil || l.Last() != nil {
+		t.Fatal("empty skiplist returned elements")
+	}
+}
+
+func TestRange(t *testing.T) {
+	bounds := []uskiplist.Bounds{uskiplist.Open, uskiplist.HalfOpen, uskiplist.IncludeHi, uskiplist.Closed}
+
+	between := func(keys []int, lo, hi int, b uskiplist.Bounds) (in, out []int) {
+		in, out = []int{}, []int{}
+		for _, k := range keys {
+			above := k > lo || b&uskiplist.IncludeLo != 0 && k == lo
+			below := k < hi || b&uskiplist.IncludeHi != 0 && k == hi
+			if above && below {
+				in = append(in, k)
+			} else {
+				out = append(out, k)
+			}
+		}
+		return
+	}
+
+	for i := 0; i < 200; i++ {
+		l, keys := newTestList(t, 500)
+		ranked := uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: true})
+		for _, k := range keys {
+			ranked.Insert(&item2{k: k})
+		}
+
+		lo, hi := keys[rand.Intn(len(keys))], keys[rand.Intn(len(keys))]
+		if i%5 == 0 {
+			lo, hi = rand.Intn(5000)-10, rand.Intn(5000)+10
+		}
+		b := bounds[i%len(bounds)]
+		in, out := between(keys, lo, hi, b)
+
+		got := collect(func(it uskiplist.Iterator[item2]) {
+			l.IterateBetween(lo, hi, b, it)
+		})
+		if !reflect.DeepEqual(got, in) {
+			t.Fatalf("IterateBetween(%d, %d, %d) = %v, want %v", lo, hi, b, got, in)
+		}
+
+		for _, l := range []*testList{l, ranked} {
+			if n := l.DeleteRange(lo, hi, b); n != len(in) {
+				t.Fatalf("DeleteRange(%d, %d, %d) = %d, want %d", lo, hi, b, n, len(in))
+			}
+			if got := collect(l.Iterate); !reflect.DeepEqual(got, out) {
+				t.Fatalf("Iterate after DeleteRange = %v, want %v", got, out)
+			}
+			if l.Len() != len(out) {
+				t.Fatalf("Len after DeleteRange = %d, want %d", l.Len(), len(out))
+			}
+		}
+		for j, k := range out {
+			if got := ranked.IndexOf(k); got != j {
+				t.Fatalf("IndexOf(%d) after DeleteRange = %d, want %d", k, got, j)
+			}
+		}
+	}
+}
+
+type dupItem struct {
+	uskiplist.Embedder[dupItem]
+
+	k   int
+	seq int
+}
+
+func (i *dupItem) Key() int {
+	return i.k
+}
+
+func TestDuplicates(t *testing.T) {
+	l := uskiplist.NewOWith[int, dupItem](uskiplist.Options{Ranked: true, Duplicates: true})
+
+	var want []dupItem
+	for seq := 0; seq < 5000; seq++ {
+		k := rand.Intn(300)
+		l.Insert(&dupItem{k: k, seq: seq})
+		want = append(want, dupItem{k: k, seq: seq})
+	}
+	sort.SliceStable(want, func(i, j int) bool { return want[i].k < want[j].k })
+
+	check := func() {
+		t.Helper()
+		i := 0
+		l.Iterate(func(e *dupItem) bool {
+			if e.k != want[i].k || e.seq != want[i].seq {
+				t.Fatalf("element %d = (%d, %d), want (%d, %d)", i, e.k, e.seq, want[i].k, want[i].seq)
+			}
+			i++
+			return true
+		})
+		if i != len(want) || l.Len() != len(want) {
+			t.Fatalf("Iterate visited %d, Len = %d, want %d", i, l.Len(), len(want))
+		}
+
+		i = len(want)
+		l.IterateReverse(func(e *dupItem) bool {
+			i--
+			if e.seq != want[i].seq {
+				t.Fatalf("reverse element %d seq = %d, want %d", i, e.seq, want[i].seq)
+			}
+			return true
+		})
+		if i != 0 {
+			t.Fatalf("IterateReverse stopped at %d", i)
+		}
+	}
+	check()
+
+	for k := 0; k < 300; k += 3 {
+		first := sort.Search(len(want), func(i int) bool { return want[i].k >= k })
+		if first == len(want) || want[first].k != k {
+			continue
+		}
+
+		if e := l.Get(k); e == nil || e.seq != want[first].seq {
+			t.Fatalf("Get(%d) = %v, want seq %d", k, e, want[first].seq)
+		}
+		if got := l.IndexOf(k); got != first {
+			t.Fatalf("IndexOf(%d) = %d, want %d", k, got, first)
+		}
+		if e := l.Floor(k); e == nil || e.k != k {
+			t.Fatalf("Floor(%d) = %v", k, e)
+		}
+
+		if k%2 == 0 {
+			l.Delete(k)
+			want = append(want[:first], want[first+1:]...)
+		} else {
+			last := sort.Search(len(want), func(i int) bool { return want[i].k > k })
+			if n := l.DeleteRange(k, k, uskiplist.Closed); n != last-first {
+				t.Fatalf("DeleteRange(%d) = %d, want %d", k, n, last-first)
+			}
+			want = append(want[:first], want[last:]...)
+		}
+	}
+	check()
+}
+
+func TestBuildFromSorted(t *testing.T) {
+	for _, opts := range []uskiplist.Options{{}, {Ranked: true}} {
+		for _, det := range []bool{false, true} {
+			const n = 40000
+			elems := make([]*item2, n)
+			for i := range elems {
+				elems[i] = &item2{k: i * 2}
+			}
+			// Out of order and equal keys are rejected.
+			elems[100] = &item2{k: 0}
+			elems[101] = &item2{k: 198}
+
+			l := uskiplist.NewOWith[int, item2](opts)
+			l.Insert(&item2{k: -1})
+			got := l.BuildFromSorted(elems, uskiplist.BuildOptions{Deterministic: det})
+			if got != n-2 || l.Len() != n-2 {
+				t.Fatalf("ranked=%v det=%v BuildFromSorted = %d, Len = %d, want %d", opts.Ranked, det, got, l.Len(), n-2)
+			}
+
+			// Continue with a builder, then modify as usual.
+			b := uskiplist.NewBuilderO(l, uskiplist.BuildOptions{Deterministic: det})
+			for k := n * 2; k < n*3; k += 2 {
+				if !b.Append(&item2{k: k}) {
+					t.Fatalf("Append(%d) rejected", k)
+				}
+			}
+			if b.Append(&item2{k: 1}) {
+				t.Fatal("Append out of order accepted")
+			}
+			l.Insert(&item2{k: 201})
+			l.Delete(0)
+
+			var keys []int
+			for k := 2; k < n*3; k += 2 {
+				switch k {
+				case 200:
+					keys = append(keys, 201)
+				case 202:
+				default:
+					keys = append(keys, k)
+				}
+			}
+			if got := collect(l.Iterate); !reflect.DeepEqual(got, keys) {
+				t.Fatalf("ranked=%v det=%v Iterate mismatch, len %d, want %d", opts.Ranked, det, len(got), len(keys))
+			}
+			if got := collect(l.IterateReverse); !reflect.DeepEqual(got, reversed(keys)) {
+				t.Fatalf("ranked=%v det=%v IterateReverse mismatch", opts.Ranked, det)
+			}
+			for _, i := range []int{0, 99, 100, 101, len(keys) / 2, len(keys) - 1} {
+				if e := l.At(i); e == nil || e.k != keys[i] {
+					t.Fatalf("ranked=%v det=%v At(%d) = %v, want %d", opts.Ranked, det, i, e, keys[i])
+				}
+				if got := l.IndexOf(keys[i]); got != i {
+					t.Fatalf("ranked=%v det=%v IndexOf(%d) = %d, want %d", opts.Ranked, det, keys[i], got, i)
+				}
+				if e := l.Get(keys[i]); e == nil {
+					t.Fatalf("ranked=%v det=%v Get(%d) = nil", opts.Ranked, det, keys[i])
+				}
+			}
+		}
+	}
+
+	l := uskiplist.NewOWith[int, item2](uskiplist.Options{Duplicates: true})
+	got := l.BuildFromSorted([]*item2{{k: 1}, {k: 1}, {k: 0}, {k: 2}}, uskiplist.BuildOptions{})
+	if got != 3 || !reflect.DeepEqual(collect(l.Iterate), []int{1, 1, 2}) {
+		t.Fatalf("duplicates BuildFromSorted = %d, %v", got, collect(l.Iterate))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Strict BuildFromSorted did not panic")
+		}
+	}()
+	l.BuildFromSorted([]*item2{{k: 1}, {k: 0}}, uskiplist.BuildOptions{Strict: true})
+}
+
+func TestListF(t *testing.T) {
+	for _, opts := range []uskiplist.Options{{}, {Ranked: true}} {
+		desc := func(a, b int) bool { return a > b }
+		l := uskiplist.NewFWith[int, item2](desc, opts)
+
+		const n = 3000
+		perm := rand.Perm(n)
+		for _, k := range perm {
+			l.Insert(&item2{k: k})
+		}
+
+		keys := make([]int, n)
+		for i := range keys {
+			keys[i] = n - 1 - i
+		}
+		if got := collect(l.Iterate); !reflect.DeepEqual(got, keys) {
+			t.Fatalf("ranked=%v Iterate is not descending", opts.Ranked)
+		}
+		if got := collect(l.IterateReverse); !reflect.DeepEqual(got, reversed(keys)) {
+			t.Fatalf("ranked=%v IterateReverse is not ascending", opts.Ranked)
+		}
+		if got := collect(func(it uskiplist.Iterator[item2]) { l.IterateFrom(5, it) }); !reflect.DeepEqual(got, []int{5, 4, 3, 2, 1, 0}) {
+			t.Fatalf("ranked=%v IterateFrom(5) = %v", opts.Ranked, got)
+		}
+		if e := l.Higher(10); e == nil || e.k != 9 {
+			t.Fatalf("ranked=%v Higher(10) = %v, want 9", opts.Ranked, e)
+		}
+		if e := l.Floor(n); e != nil {
+			t.Fatalf("ranked=%v Floor(n) = %v, want nil", opts.Ranked, e)
+		}
+		if i := l.IndexOf(n - 10); i != 9 {
+			t.Fatalf("ranked=%v IndexOf(n-10) = %d, want 9", opts.Ranked, i)
+		}
+
+		if got := l.DeleteRange(100, 10, uskiplist.Closed); got != 91 {
+			t.Fatalf("ranked=%v DeleteRange = %d, want 91", opts.Ranked, got)
+		}
+		l.Delete(0)
+		if l.Len() != n-92 || l.Get(50) != nil || l.Get(9) == nil {
+			t.Fatalf("ranked=%v Len = %d after deletes", opts.Ranked, l.Len())
+		}
+
+		b := uskiplist.NewFWith[int, item2](desc, opts)
+		if got := b.BuildFromSorted([]*item2{{k: 3}, {k: 2}, {k: 2}, {k: 5}, {k: 1}}, uskiplist.BuildOptions{}); got != 3 {
+			t.Fatalf("ranked=%v BuildFromSorted = %d, want 3", opts.Ranked, got)
+		}
+		if got := collect(b.Iterate); !reflect.DeepEqual(got, []int{3, 2, 1}) {
+			t.Fatalf("ranked=%v BuildFromSorted = %v", opts.Ranked, got)
+		}
+	}
+}
+
+func TestCursor(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 100, 5000} {
+		l, keys := newTestList(t, n)
+
+		got := []int{}
+		for c := l.Cursor(); c.Valid(); c.Next() {
+			got = append(got, c.Elem().k)
+		}
+		if !reflect.DeepEqual(got, append([]int{}, keys...)) {
+			t.Fatalf("n=%d Cursor = %v, want %v", n, got, keys)
+		}
+
+		if n == 0 {
+			c := l.Cursor()
+			c.Seek(1)
+			if c.Valid() || c.Elem() != nil {
+				t.Fatal("Cursor of an empty list is valid")
+			}
+			continue
+		}
+
+		c := l.Cursor()
+		c.Seek(keys[n/2] - 1)
+		if i := sort.SearchInts(keys, keys[n/2]-1); c.Elem().k != keys[i] {
+			t.Fatalf("n=%d Seek = %d, want %d", n, c.Elem().k, keys[i])
+		}
+		c.Seek(n * 10)
+		if c.Valid() {
+			t.Fatalf("n=%d Seek past the end is valid", n)
+		}
+		c.Next()
+		if c.Valid() {
+			t.Fatalf("n=%d Next of an invalid cursor is valid", n)
+		}
+	}
+
+	// Delete the current element, and insert around it between steps.
+	l, keys := newTestList(t, 3000)
+	present := make(map[int]bool)
+	for _, k := range keys {
+		present[k] = true
+	}
+	var got []int
+	for c := l.Cursor(); c.Valid(); c.Next() {
+		k := c.Elem().k
+		got = append(got, k)
+		switch k % 3 {
+		case 0:
+			l.Delete(k)
+		case 1:
+			// Inserted before the cursor, never visited.
+			if !present[k-1] {
+				l.Insert(&item2{k: k - 1})
+			}
+		}
+	}
+	if !reflect.DeepEqual(got, keys) {
+		t.Fatalf("Cursor with modifications visited %d keys, want %d", len(got), len(keys))
+	}
+
+	// Walk two lists in lockstep.
+	a, ka := newTestList(t, 1000)
+	b, kb := newTestList(t, 1000)
+	var merged []int
+	ca, cb := a.Cursor(), b.Cursor()
+	for ca.Valid() || cb.Valid() {
+		if !cb.Valid() || ca.Valid() && ca.Elem().k <= cb.Elem().k {
+			merged = append(merged, ca.Elem().k)
+			ca.Next()
+		} else {
+			merged = append(merged, cb.Elem().k)
+			cb.Next()
+		}
+	}
+	want := append(append([]int{}, ka...), kb...)
+	sort.Ints(want)
+	if !reflect.DeepEqual(merged, want) {
+		t.Fatal("lockstep merge is not sorted")
+	}
+}
+
+func TestStats(t *testing.T) {
+	for _, ranked := range []bool{false, true} {
+		l := uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: ranked})
+		s := l.Stats()
+		if s.Len != 0 || s.MaxLevel != uskiplist.InitialLevel || s.PointerWords != 0 || s.ArrayBytes == 0 {
+			t.Fatalf("ranked=%v empty Stats = %+v", ranked, s)
+		}
+
+		const n = 100000
+		l.BuildFromSorted(func() []*item2 {
+			elems := make([]*item2, n)
+			for i := range elems {
+				elems[i] = &item2{k: i}
+			}
+			return elems
+		}(), uskiplist.BuildOptions{Deterministic: true})
+
+		s = l.Stats()
+		if s.Len != n || len(s.Levels) != s.MaxLevel || s.Levels[0] != n {
+			t.Fatalf("ranked=%v Stats = %+v", ranked, s)
+		}
+		for i := 1; i < len(s.Levels); i++ {
+			if want := n >> (2 * i); s.Levels[i] != want {
+				t.Fatalf("ranked=%v Levels[%d] = %d, want %d", ranked, i, s.Levels[i], want)
+			}
+		}
+		if s.PointerWords < 1.55 || s.PointerWords > 1.6 {
+			t.Fatalf("ranked=%v PointerWords = %v, want about 1.58", ranked, s.PointerWords)
+		}
+		if s.SearchDepth < 5 || s.SearchDepth > 30 {
+			t.Fatalf("ranked=%v SearchDepth = %v", ranked, s.SearchDepth)
+		}
+	}
+}
+
+func TestSource(t *testing.T) {
+	shape := func(seed int64) []int {
+		l := uskiplist.NewOWith[int, item2](uskiplist.Options{Source: uskiplist.NewSource(seed)})
+		for _, k := range rand.New(rand.NewSource(1)).Perm(5000) {
+			l.Insert(&item2{k: k})
+		}
+		return l.Stats().Levels
+	}
+
+	a, b := shape(42), shape(42)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("same seed, different shapes %v and %v", a, b)
+	}
+	if c := shape(43); reflect.DeepEqual(a, c) {
+		t.Fatalf("different seeds, same shape %v", a)
+	}
+}
+
+func TestProbability(t *testing.T) {
+	const n = 1 << 12
+	l := uskiplist.NewOWith[int, item2](uskiplist.Options{Probability: 0.5})
+	l.BuildFromSorted(func() []*item2 {
+		elems := make([]*item2, n)
+		for i := range elems {
+			elems[i] = &item2{k: i}
+		}
+		return elems
+	}(), uskiplist.BuildOptions{Deterministic: true})
+
+	s := l.Stats()
+	if s.MaxLevel != 16 {
+		t.Fatalf("MaxLevel = %d, want 16", s.MaxLevel)
+	}
+	for i := 1; i < len(s.Levels); i++ {
+		if want := n >> i; s.Levels[i] != want {
+			t.Fatalf("Levels[%d] = %d, want %d", i, s.Levels[i], want)
+		}
+	}
+
+	var got []int
+	l.Sample(64, func(e *item2) bool {
+		got = append(got, e.k)
+		return true
+	})
+	// the first element is always visited
+	if len(got) != n/64+1 {
+		t.Fatalf("Sample(64) visited %d elements, want %d", len(got), n/64+1)
+	}
+
+	for _, p := range []float32{-0.5, 1, 2} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Probability %v did not panic", p)
+				}
+			}()
+			uskiplist.NewOWith[int, item2](uskiplist.Options{Probability: p})
+		}()
+	}
+}
+
+func TestLevelLimit(t *testing.T) {
+	l := uskiplist.NewOWith[int, item2](uskiplist.Options{
+		LevelLimit: func(n int) int { return 2 + n/1000 },
+	})
+	if got := l.Stats().MaxLevel; got != 2 {
+		t.Fatalf("empty MaxLevel = %d, want 2", got)
+	}
+	for _, k := range rand.Perm(5000) {
+		l.Insert(&item2{k: k})
+	}
+	// the limit is raised on links above level 1
+	if got := l.Stats().MaxLevel; got < 6 || got > 7 {
+		t.Fatalf("MaxLevel = %d, want 6 or 7", got)
+	}
+	if got := collect(l.Iterate); len(got) != 5000 || !sort.IntsAreSorted(got) {
+		t.Fatalf("Iterate visited %d elements", len(got))
+	}
+}
+
+func TestShrink(t *testing.T) {
+	for _, ranked := range []bool{false, true} {
+		l := uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: ranked})
+		keys := rand.Perm(50000)
+		for _, k := range keys {
+			l.Insert(&item2{k: k})
+		}
+		if got := l.Stats().MaxLevel; got != 16 {
+			t.Fatalf("ranked=%v MaxLevel = %d, want 16", ranked, got)
+		}
+
+		for _, k := range keys[:49980] {
+			l.Delete(k)
+		}
+		s := l.Stats()
+		if s.MaxLevel != 4 || len(s.Levels) != 4 {
+			t.Fatalf("ranked=%v MaxLevel after deletion = %d, want 4", ranked, s.MaxLevel)
+		}
+
+		rest := append([]int{}, keys[49980:]...)
+		sort.Ints(rest)
+		if got := collect(l.Iterate); !reflect.DeepEqual(got, rest) {
+			t.Fatalf("ranked=%v Iterate after shrink = %v, want %v", ranked, got, rest)
+		}
+		if ranked {
+			for i, k := range rest {
+				if e := l.At(i); e == nil || e.k != k {
+					t.Fatalf("At(%d) = %v, want %d", i, e, k)
+				}
+			}
+		}
+
+		// grow again
+		for _, k := range keys[:49980] {
+			l.Insert(&item2{k: k})
+		}
+		if got := l.Stats().MaxLevel; got != 16 {
+			t.Fatalf("ranked=%v MaxLevel after growth = %d, want 16", ranked, got)
+		}
+		if ranked {
+			for _, i := range []int{0, 12345, 49999} {
+				if e := l.At(i); e == nil || e.k != i {
+					t.Fatalf("At(%d) = %v, want %d", i, e, i)
+				}
+			}
+		}
+	}
+}
+
+func TestShrinkRegrow(t *testing.T) {
+	for _, ranked := range []bool{false, true} {
+		src := &levelSource{}
+		l := uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: ranked, Source: src})
+		items := make([]item2, 200)
+		for i := range items {
+			items[i].k = i
+			l.Insert(&items[i])
+		}
+		tall := make([]item2, 2)
+
+		// raise the limit to 8 with a level 2 element, then shrink it to 4
+		run := 0
+		cycle := func(check bool) {
+			e := &tall[run%2]
+			run++
+			e.k = -1
+			src.raise = true
+			l.Insert(e)
+			if check && l.Stats().MaxLevel != 8 {
+				t.Fatalf("ranked=%v MaxLevel = %d, want 8", ranked, l.Stats().MaxLevel)
+			}
+			l.Delete(-1)
+			for i := 10; i < len(items); i++ {
+				l.Delete(i)
+			}
+			if check && l.Stats().MaxLevel != 4 {
+				t.Fatalf("ranked=%v MaxLevel after shrink = %d, want 4", ranked, l.Stats().MaxLevel)
+			}
+			for i := 10; i < len(items); i++ {
+				l.Insert(&items[i])
+			}
+		}
+		cycle(true)
+
+		// only the array of the level 2 element is allocated, the root
+		// kept by shrink is long enough
+		want := 1.0
+		if ranked {
+			want = 2 // and its span array
+		}
+		allocs := testing.AllocsPerRun(10, func() { cycle(false) })
+		if allocs != want {
+			t.Fatalf("ranked=%v shrink and regrow allocates %v times, want %v", ranked, allocs, want)
+		}
+
+		// all elements have level 1, the root keeps 8 levels
+		bytes := 8 * int(unsafe.Sizeof(uintptr(0)))
+		if ranked {
+			bytes += int(unsafe.Sizeof(uintptr(0))) + 8*int(unsafe.Sizeof(0))
+		}
+		if s := l.Stats(); s.ArrayBytes != bytes {
+			t.Fatalf("ranked=%v ArrayBytes = %d, want %d", ranked, s.ArrayBytes, bytes)
+		}
+		if ranked {
+			for i := range items {
+				if e := l.At(i); e != &items[i] {
+					t.Fatalf("At(%d) = %v, want %d", i, e, i)
+				}
+			}
+		}
+	}
+}
+
+func TestShrinkIterateReverse(t *testing.T) {
+	// a low limit, so the deletions keep lowering it below the pending
+	// frames of the reverse iteration
+	l := uskiplist.NewOWith[int, item2](uskiplist.Options{
+		Probability: 0.5,
+		LevelLimit:  func(n int) int { return 2 + bits.Len(uint(n))/2 },
+	})
+	keys := rand.Perm(40000)
+	for _, k := range keys {
+		l.Insert(&item2{k: k})
+	}
+	sort.Ints(keys)
+
+	var got []int
+	l.IterateReverse(func(e *item2) bool {
+		got = append(got, e.k)
+		l.Delete(e.k)
+		return true
+	})
+	if want := reversed(keys); !reflect.DeepEqual(got, want) {
+		t.Fatalf("IterateReverse with deletion visited %d elements, want %d", len(got), len(want))
+	}
+	if s := l.Stats(); s.Len != 0 || s.MaxLevel != 2 {
+		t.Fatalf("Stats after deletion = %+v", s)
+	}
+}
+
+func TestNoShrink(t *testing.T) {
+	l := uskiplist.NewOWith[int, item2](uskiplist.Options{NoShrink: true})
+	for k := 0; k < 1000; k++ {
+		l.Insert(&item2{k: k})
+	}
+	l.DeleteRange(0, 1000, uskiplist.IncludeLo)
+	if s := l.Stats(); s.Len != 0 || s.MaxLevel != 8 {
+		t.Fatalf("Stats after deletion = %+v", s)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	for _, ranked := range []bool{false, true} {
+		l := uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: ranked})
+		present := make(map[int]*item2)
+		for i := 0; i < 20000; i++ {
+			k := rand.Intn(2000)
+			op := rand.Intn(3)
+			got := l.Compute(k, func(e *item2) *item2 {
+				if e != present[k] {
+					t.Fatalf("ranked=%v Compute(%d) found %v, want %v", ranked, k, e, present[k])
+				}
+				switch op {
+				case 0:
+					return nil
+				case 1:
+					return &item2{k: k}
+				}
+				return e
+			})
+			if op == 2 {
+				continue
+			}
+			if got == nil {
+				delete(present, k)
+			} else {
+				present[k] = got
+			}
+		}
+
+		var keys []int
+		for k := range present {
+			keys = append(keys, k)
+		}
+		sort.Ints(keys)
+		if got := collect(l.Iterate); !reflect.DeepEqual(got, keys) {
+			t.Fatalf("ranked=%v Iterate = %v, want %v", ranked, got, keys)
+		}
+		for i, k := range keys {
+			if e := l.Get(k); e != present[k] {
+				t.Fatalf("ranked=%v Get(%d) = %p, want %p", ranked, k, e, present[k])
+			}
+			if ranked {
+				if e := l.At(i); e != present[k] {
+					t.Fatalf("At(%d) = %v, want %d", i, e, k)
+				}
+			}
+		}
+	}
+}
+
+func TestInsertOrGet(t *testing.T) {
+	for _, ranked := range []bool{false, true} {
+		l := uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: ranked})
+		items := make(map[int]*item2)
+		for i := 0; i < 3000; i++ {
+			k := rand.Intn(1000)
+			e := &item2{k: k}
+			existing, inserted := l.InsertOrGet(e)
+			if want := items[k]; existing != want || inserted != (want == nil) {
+				t.Fatalf("ranked=%v InsertOrGet(%d) = %p, %v, want %p", ranked, k, existing, inserted, want)
+			}
+			if inserted {
+				items[k] = e
+			}
+		}
+
+		for k := 0; k < 1000; k += 3 {
+			e := &item2{k: k}
+			if old := l.Replace(e); old != items[k] {
+				t.Fatalf("ranked=%v Replace(%d) = %p, want %p", ranked, k, old, items[k])
+			}
+			items[k] = e
+		}
+		// an element already in place stays linked
+		for k := 0; k < 1000; k += 7 {
+			if e := items[k]; e != nil {
+				if old := l.Replace(e); old != e {
+					t.Fatalf("ranked=%v Replace(linked %d) = %p, want %p", ranked, k, old, e)
+				}
+			}
+		}
+		for k := 0; k < 1000; k += 5 {
+			if e := l.Remove(k); e != items[k] {
+				t.Fatalf("ranked=%v Remove(%d) = %p, want %p", ranked, k, e, items[k])
+			}
+			delete(items, k)
+		}
+
+		var keys []int
+		for k := range items {
+			keys = append(keys, k)
+		}
+		sort.Ints(keys)
+		if got := collect(l.Iterate); !reflect.DeepEqual(got, keys) {
+			t.Fatalf("ranked=%v Iterate = %v, want %v", ranked, got, keys)
+		}
+		for i, k := range keys {
+			if e := l.Get(k); e != items[k] {
+				t.Fatalf("ranked=%v Get(%d) = %p, want %p", ranked, k, e, items[k])
+			}
+			if got := l.IndexOf(k); ranked && got != i {
+				t.Fatalf("IndexOf(%d) = %d, want %d", k, got, i)
+			}
+		}
+	}
+}
+
+func TestArrayPool(t *testing.T) {
+	pool := uskiplist.NewArrayPool()
+	for _, ranked := range []bool{false, true} {
+		elems := make([]item2, 2000)
+		for i := range elems {
+			elems[i].k = i
+		}
+
+		var l *testList
+		churn := func() {
+			for _, i := range rand.Perm(len(elems)) {
+				l.Insert(&elems[i])
+			}
+			for i := range elems {
+				if e := l.Remove(i); e != &elems[i] {
+					t.Fatalf("ranked=%v Remove(%d) = %p, want %p", ranked, i, e, &elems[i])
+				}
+			}
+		}
+
+		l = uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: ranked})
+		unpooled := testing.AllocsPerRun(10, churn)
+		l = uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: ranked, Pool: pool})
+		churn()
+		// the race detector makes the pool drop some arrays
+		if allocs := testing.AllocsPerRun(10, churn); allocs > unpooled/2 {
+			t.Fatalf("ranked=%v churn allocates %v times, %v without pool", ranked, allocs, unpooled)
+		}
+
+		for _, i := range rand.Perm(len(elems)) {
+			l.Insert(&elems[i])
+		}
+		l.DeleteRange(500, 1500, uskiplist.HalfOpen)
+		var keys []int
+		for i := range elems {
+			if i < 500 || i >= 1500 {
+				keys = append(keys, i)
+			}
+		}
+		if got := collect(l.Iterate); !reflect.DeepEqual(got, keys) {
+			t.Fatalf("ranked=%v Iterate = %v, want %v", ranked, got, keys)
+		}
+		if ranked {
+			for i, k := range keys {
+				if e := l.At(i); e == nil || e.k != k {
+					t.Fatalf("At(%d) = %v, want %d", i, e, k)
+				}
+			}
+		}
+	}
+}
+
+// levelSource makes every element level 1, except the next one linked after
+// raise is set, which gets level 2, and all while tall is set, which get the
+// level limit.
+type levelSource struct {
+	raise bool
+	tall  bool
+}
+
+func (s *levelSource) Seed(int64) {}
+
+func (s *levelSource) Uint64() uint64 { return uint64(s.Int63()) }
+
+func (s *levelSource) Int63() int64 {
+	if s.tall {
+		return 0
+	}
+	if s.raise {
+		s.raise = false
+		return 0
+	}
+	return 65535
+}
+
+func TestArrayPoolShrink(t *testing.T) {
+	pool := uskiplist.NewArrayPool()
+	for _, ranked := range []bool{false, true} {
+		src := &levelSource{}
+		l := uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: ranked, Source: src, Pool: pool})
+		items := make([]item2, 200)
+		tall := make([]item2, 20)
+		for i := range items {
+			items[i].k = i
+		}
+		for i := range tall {
+			tall[i].k = 1000 + i
+		}
+
+		// The first tall element raises the limit to 8, the others get 8
+		// levels. Removing the items shrinks the limit to 4 below them, they
+		// must still go back to the pool of 8 levels.
+		cycle := func() {
+			for i := range items {
+				l.Insert(&items[i])
+			}
+			src.tall = true
+			for i := range tall {
+				l.Insert(&tall[i])
+			}
+			src.tall = false
+			for i := range items {
+				l.Delete(i)
+			}
+			for i := range tall {
+				if e := l.Remove(1000 + i); e != &tall[i] {
+					t.Fatalf("ranked=%v Remove(%d) = %p, want %p", ranked, 1000+i, e, &tall[i])
+				}
+			}
+		}
+		cycle()
+
+		misses := float64(len(tall) - 1)
+		if ranked {
+			misses *= 2 // and the span arrays
+		}
+		// the race detector makes the pool drop some arrays
+		if allocs := testing.AllocsPerRun(10, cycle); allocs > misses/2 {
+			t.Fatalf("ranked=%v cycle allocates %v times, %v without reuse", ranked, allocs, misses)
+		}
+	}
+}
+
+func TestEmbedderReset(t *testing.T) {
+	l := uskiplist.NewO[int, item2]()
+	elems := make([]item2, 100)
+	for i := range elems {
+		elems[i].k = i
+		l.Insert(&elems[i])
+	}
+	for i := range elems {
+		e := l.Remove(i)
+		e.Reset()
+		e.k += 100
+		l.Insert(e)
+	}
+	if got := collect(l.Iterate); len(got) != 100 || got[0] != 100 || got[99] != 199 {
+		t.Fatalf("Iterate after reuse = %v", got)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist
+
+// First returns the first element, returns nil when the skiplist is empty.
+func (l *listBase[E, PE]) First() *E {
+	return l.root[0]
+}
+
+// Last returns the last element, returns nil when the skiplist is empty.
+func (l *listBase[E, PE]) Last() *E {
+	var last *E
+
+	ln := l.root
+	for i := l.maxL - 1; i > 0; i-- {
+		for ln[i] != nil {
+			last = ln[i]
+			ln = PE(last).lnNext()
+		}
+	}
+
+	cur := ln[0]
+	for cur != nil {
+		last = cur
+		cur = PE(cur).l1Next()[0]
+	}
+
+	return last
+}
+
+// PopFirst removes and returns the first element, returns nil when the
+// skiplist is empty.
+func (l *listBase[E, PE]) PopFirst() *E {
+	e := l.root[0]
+	if e == nil {
+		return nil
+	}
+
+	path := &searchPath[E]{}
+	for i := 0; i < l.maxL; i++ {
+		path[i] = &l.root[i]
+	}
+
+	l.unlink(e, path)
+	return e
+}
+
+// PopLast removes and returns the last element, returns nil when the
+// skiplist is empty.
+func (l *listBase[E, PE]) PopLast() *E {
+	e := l.Last()
+	if e == nil {
+		return nil
+	}
+
+	path := &searchPath[E]{}
+	ln := l.root
+	for i := l.maxL - 1; i > 0; i-- {
+		for ln[i] != nil && ln[i] != e {
+			ln = PE(ln[i]).lnNext()
+		}
+		path[i] = &ln[i]
+	}
+
+	slot := &ln[0]
+	for *slot != e {
+		slot = &PE(*slot).l1Next()[0]
+	}
+	path[0] = slot
+
+	l.unlink(e, path)
+	return e
+}
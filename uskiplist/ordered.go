@@ -5,7 +5,6 @@
 package uskiplist
 
 import (
-	"math"
 	"unsafe"
 
 	"github.com/someonegg/gocontainer/cmp"
@@ -21,7 +20,7 @@ type ElementO[K cmp.Ordered, E any] interface {
 }
 
 type ListO[K cmp.Ordered, E any, PE ElementO[K, E]] struct {
-	listBase[E]
+	listBase[E, PE]
 }
 
 // NewO creates and initializes a new skiplist, limiting the key to Ordered type.
@@ -31,11 +30,25 @@ func NewO[K cmp.Ordered, E any, PE ElementO[K, E]]() *ListO[K, E, PE] {
 	return l
 }
 
-// Init initializes the skiplist.
+// NewOWith creates and initializes a new skiplist with options, limiting the
+// key to Ordered type.
+func NewOWith[K cmp.Ordered, E any, PE ElementO[K, E]](opts Options) *ListO[K, E, PE] {
+	l := &ListO[K, E, PE]{}
+	l.InitWith(opts)
+	return l
+}
+
+// Init initializes the skiplist, keeping the options.
 func (l *ListO[K, E, PE]) Init() {
 	l.init()
 }
 
+// InitWith initializes the skiplist with options.
+func (l *ListO[K, E, PE]) InitWith(opts Options) {
+	l.opts = opts
+	l.init()
+}
+
 // Len returns number of elements in the skiplist.
 func (l *ListO[K, E, PE]) Len() int { return l.len }
 
@@ -44,66 +57,123 @@ func (l *ListO[K, E, PE]) Get(k K) *E {
 	return l.search(k, l.idealLevel(), nil)
 }
 
-// Insert inserts a new element, do nothing when found.
+// IndexOf returns the index (0-based) of the specified element, returns -1
+// when not found. It takes O(log n) in a ranked skiplist, and O(n) otherwise.
+func (l *ListO[K, E, PE]) IndexOf(k K) int {
+	path := &searchPath[E]{}
+	e := l.search(k, l.maxL, path)
+	return l.indexOf(e, path)
+}
+
+// Insert inserts a new element, do nothing when found. When duplicates are
+// allowed, it is inserted after the elements with equal key.
 func (l *ListO[K, E, PE]) Insert(e *E) {
+	l.InsertOrGet(e)
+}
+
+// InsertOrGet inserts a new element and returns true, or returns the element
+// found and false. When duplicates are allowed, it is always inserted after
+// the elements with equal key.
+func (l *ListO[K, E, PE]) InsertOrGet(e *E) (existing *E, inserted bool) {
 	path := &searchPath[E]{}
 	lev := l.maxL
 
-	if l.search(PE(e).Key(), lev, path) != nil {
-		return
+	if l.opts.Duplicates {
+		l.searchUpper(PE(e).Key(), lev, path)
+	} else if existing = l.search(PE(e).Key(), lev, path); existing != nil {
+		return existing, false
 	}
 
-	lev = l.randLevel()
-
-	// fast path
-	if lev == 1 {
-		l1 := PE(e).l1Next()
-		l1[0] = *path[0]
-		*path[0] = e
-		l.len++
-		return
-	}
+	l.link(e, path)
+	return nil, true
+}
 
-	*(PE(e).ptNext()) = makePointArray(lev)
+// Replace puts a new element in place of the element found and returns the
+// old one, which is unlinked. It inserts the new element and returns nil when
+// not found. When duplicates are allowed, the first one is replaced. It does
+// nothing and returns e when e itself is found.
+func (l *ListO[K, E, PE]) Replace(e *E) (old *E) {
+	path := &searchPath[E]{}
+	lev := l.maxL
 
-	ln := PE(e).lnNext()
-	for i := lev - 1; i >= 0; i-- {
-		ln[i] = *path[i]
-		*path[i] = e
+	old = l.search(PE(e).Key(), lev, path)
+	switch old {
+	case nil:
+		l.link(e, path)
+	case e:
+	default:
+		l.replace(old, e, path)
 	}
-
-	l.len++
-	l.adjust()
+	return old
 }
 
 // Delete remove the element from the skiplist, do nothing when not found.
 func (l *ListO[K, E, PE]) Delete(k K) {
+	l.Remove(k)
+}
+
+// Remove removes the element from the skiplist and returns it, returns nil
+// when not found.
+func (l *ListO[K, E, PE]) Remove(k K) *E {
 	path := &searchPath[E]{}
 	lev := l.maxL
 
 	e := l.search(k, lev, path)
 	if e == nil {
-		return
+		return nil
 	}
 
-	// fast path
-	if *path[1] != e {
-		l1 := PE(e).l1Next()
-		*path[0] = l1[0]
-		l1[0] = nil
-		l.len--
-		return
+	l.unlink(e, path)
+	return e
+}
+
+// Compute searches for k once and calls fn with the element found, or nil.
+// fn returns the element to keep at k: the found one to leave it, nil to
+// remove it, or another element with key k to insert it or to replace the
+// found one in place. It returns the element at k afterwards.
+//
+// When duplicates are allowed, the first element with key k is found. fn must
+// not modify the skiplist.
+func (l *ListO[K, E, PE]) Compute(k K, fn func(e *E) *E) *E {
+	path := &searchPath[E]{}
+	e := l.search(k, l.maxL, path)
+
+	n := fn(e)
+	switch {
+	case n == e:
+	case n == nil:
+		l.unlink(e, path)
+	case e == nil:
+		l.link(n, path)
+	default:
+		l.replace(e, n, path)
 	}
+	return n
+}
 
-	ln := PE(e).lnNext()
-	for i := lev - 1; i >= 0; i-- {
-		if *path[i] == e {
-			*path[i] = ln[i]
-			ln[i] = nil
-		}
+// DeleteRange removes all elements between lo and hi in one pass, bounds
+// tells whether lo and hi are included. It returns the number of removed
+// elements.
+func (l *ListO[K, E, PE]) DeleteRange(lo, hi K, bounds Bounds) int {
+	path := &searchPath[E]{}
+	l.searchBound(lo, bounds, l.maxL, path)
+
+	n := 0
+	for e := *path[0]; e != nil && l.belowBound(e, hi, bounds); e = *path[0] {
+		l.unlink(e, path)
+		n++
 	}
+	return n
+}
+
+// Cursor returns a new cursor at the first element, see Cursor.
+func (l *ListO[K, E, PE]) Cursor() *Cursor[K, E, PE] {
+	return newCursor[K, E, PE](&l.listBase, l)
+}
 
-	l.len--
+// Finger returns a new search finger, see Finger.
+func (l *ListO[K, E, PE]) Finger() *Finger[K, E, PE] {
+	return newFinger[K, E, PE](&l.listBase, l)
 }
 
 // Iterate will call iterator once for each element in ascending order.
@@ -138,38 +208,88 @@ func (l *ListO[K, E, PE]) IterateFrom(pivot K, iterator Iterator[E]) {
 	l.iterate(cur, *path[1], iterator)
 }
 
-func (l *ListO[K, E, PE]) iterate(cur, relay *E, iterator Iterator[E]) {
-	for {
-		for cur != nil {
-			save := cur
+// IterateAfter will call iterator once for each element greater than pivot
+// in ascending order.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *ListO[K, E, PE]) IterateAfter(pivot K, iterator Iterator[E]) {
+	var cur *E
 
-			l1 := PE(cur).l1Next()
-			cur = l1[0]
-			if cur == relay {
-				cur = nil
-			}
+	path := &searchPath[E]{}
+	l.searchUpper(pivot, l.idealLevel(), path)
+	if *path[0] != *path[1] {
+		cur = *path[0]
+	}
 
-			if !iterator(save) {
-				return
-			}
-		}
+	l.iterate(cur, *path[1], iterator)
+}
 
-		if relay == nil {
-			break
-		}
+// IterateBetween will call iterator once for each element between lo and hi
+// in ascending order, bounds tells whether lo and hi are included.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *ListO[K, E, PE]) IterateBetween(lo, hi K, bounds Bounds, iterator Iterator[E]) {
+	var cur *E
 
-		save := relay
+	path := &searchPath[E]{}
+	l.searchBound(lo, bounds, l.idealLevel(), path)
+	if *path[0] != *path[1] {
+		cur = *path[0]
+	}
 
-		ln := PE(relay).lnNext()
-		if ln[0] != ln[1] {
-			cur = ln[0]
-		}
-		relay = ln[1]
+	l.iterate(cur, *path[1], func(e *E) bool {
+		return l.belowBound(e, hi, bounds) && iterator(e)
+	})
+}
 
-		if !iterator(save) {
-			return
+// IterateBefore will call iterator once for each element less than pivot in
+// descending order.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *ListO[K, E, PE]) IterateBefore(pivot K, iterator Iterator[E]) {
+	stack := l.revStack()
+	for i := l.maxL - 1; i >= 0; i-- {
+		cur := l.next(stack[len(stack)-1].e, i)
+		for cur != nil && PE(cur).Key() < pivot {
+			stack = append(stack, revFrame[E]{cur, i})
+			if i > 0 {
+				cur = PE(cur).lnNext()[i]
+			} else {
+				cur = PE(cur).l1Next()[0]
+			}
 		}
 	}
+	l.reverse(stack, iterator)
+}
+
+// Floor returns the last element less than or equal to k, returns nil when
+// not found.
+func (l *ListO[K, E, PE]) Floor(k K) *E {
+	return l.before(k, true)
+}
+
+// Ceiling returns the first element greater than or equal to k, returns nil
+// when not found.
+func (l *ListO[K, E, PE]) Ceiling(k K) *E {
+	path := &searchPath[E]{}
+	l.search(k, l.idealLevel(), path)
+	return *path[0]
+}
+
+// Lower returns the last element less than k, returns nil when not found.
+func (l *ListO[K, E, PE]) Lower(k K) *E {
+	return l.before(k, false)
+}
+
+// Higher returns the first element greater than k, returns nil when not
+// found.
+func (l *ListO[K, E, PE]) Higher(k K) *E {
+	path := &searchPath[E]{}
+	l.searchUpper(k, l.idealLevel(), path)
+	return *path[0]
 }
 
 // Sample samples about one for every step elements.
@@ -182,15 +302,7 @@ func (l *ListO[K, E, PE]) Sample(step int, iterator Iterator[E]) {
 		return
 	}
 
-	lev := int(math.Round(math.Log2(float64(step))/2.0 + 1.0))
-	if lev < 2 {
-		lev = 2
-	}
-	if lev > l.maxL {
-		lev = l.maxL
-	}
-
-	i := lev - 1
+	i := l.sampleLevel(step) - 1
 
 	if l.root[0] != l.root[i] {
 		if !iterator(l.root[0]) {
@@ -254,3 +366,129 @@ func (l *ListO[K, E, PE]) search(k K, lev int, path *searchPath[E]) (e *E) {
 
 	return
 }
+
+// before returns the last element less than k, or less than or equal to k
+// when orEqual.
+func (l *ListO[K, E, PE]) before(k K, orEqual bool) *E {
+	var last *E
+
+	precedes := func(e *E) bool {
+		if orEqual {
+			return !(k < PE(e).Key())
+		}
+		return PE(e).Key() < k
+	}
+
+	ln := l.root
+	for i := l.maxL - 1; i > 0; i-- {
+		for ln[i] != nil && precedes(ln[i]) {
+			last = ln[i]
+			ln = PE(last).lnNext()
+		}
+	}
+
+	cur := ln[0]
+	for cur != nil && precedes(cur) {
+		last = cur
+		cur = PE(cur).l1Next()[0]
+	}
+
+	return last
+}
+
+// searchUpper is like search, but path is filled for the first element
+// greater than k.
+//
+// lev : [2, l.maxL]
+func (l *ListO[K, E, PE]) searchUpper(k K, lev int, path *searchPath[E]) {
+	if lev < 2 {
+		lev = 2
+	}
+	if lev > l.maxL {
+		lev = l.maxL
+	}
+
+	pre := l.root
+	for i := lev - 1; i > 0; i-- {
+		for pre[i] != nil && !(k < PE(pre[i]).Key()) {
+			pre = PE(pre[i]).lnNext()
+		}
+		path[i] = &pre[i]
+	}
+
+	path[0] = &pre[0]
+	if pre[0] != nil && !(k < PE(pre[0]).Key()) {
+		preL0 := PE(pre[0]).l1Next()
+		for preL0[0] != nil && !(k < PE(preL0[0]).Key()) {
+			preL0 = PE(preL0[0]).l1Next()
+		}
+		path[0] = &preL0[0]
+	}
+}
+
+// searchNear is like search, or searchUpper when upper, but starts from path,
+// which must be filled for the key from (by searchUpper when fromUpper). It
+// takes O(log d) when k is d elements after from, and falls back to a full
+// search when k is before from.
+func (l *ListO[K, E, PE]) searchNear(k K, upper bool, path *searchPath[E], from K, fromUpper bool) (e *E) {
+	if k < from || fromUpper && !upper && !(from < k) {
+		if upper {
+			l.searchUpper(k, l.maxL, path)
+			return nil
+		}
+		return l.search(k, l.maxL, path)
+	}
+
+	before := func(e *E) bool {
+		if upper {
+			return !(k < PE(e).Key())
+		}
+		return PE(e).Key() < k
+	}
+
+	// climb to the lowest level whose successor is not before k, the levels
+	// above it are still filled for k.
+	i := 1
+	for i < l.maxL-1 && *path[i] != nil && before(*path[i]) {
+		i++
+	}
+
+	pre := pathArray(path, i)
+	for ; i > 0; i-- {
+		for pre[i] != nil && before(pre[i]) {
+			pre = PE(pre[i]).lnNext()
+		}
+		path[i] = &pre[i]
+	}
+
+	path[0] = &pre[0]
+	if pre[0] != nil && before(pre[0]) {
+		preL0 := PE(pre[0]).l1Next()
+		for preL0[0] != nil && before(preL0[0]) {
+			preL0 = PE(preL0[0]).l1Next()
+		}
+		path[0] = &preL0[0]
+	}
+
+	if e = *path[0]; e != nil && (upper || k < PE(e).Key()) {
+		e = nil
+	}
+	return e
+}
+
+// searchBound fills path for the first element above the lower bound lo.
+func (l *ListO[K, E, PE]) searchBound(lo K, bounds Bounds, lev int, path *searchPath[E]) {
+	if bounds&IncludeLo != 0 {
+		l.search(lo, lev, path)
+	} else {
+		l.searchUpper(lo, lev, path)
+	}
+}
+
+// belowBound tells whether e is below the upper bound hi.
+func (l *ListO[K, E, PE]) belowBound(e *E, hi K, bounds Bounds) bool {
+	if bounds&IncludeHi != 0 {
+		return !(hi < PE(e).Key())
+	}
+	return PE(e).Key() < hi
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// ArrayPool recycles the point arrays, and the span arrays of ranked
+// skiplists, of elements with more than one level. See Options.Pool.
+//
+// The zero value is ready to use. It is safe for concurrent use, so it can be
+// shared by skiplists of any element type. An ArrayPool must not be copied
+// after first use.
+type ArrayPool struct {
+	// [ranked][level]
+	pools [2][MaximumLevel + 1]sync.Pool
+}
+
+// NewArrayPool creates a new, empty ArrayPool.
+func NewArrayPool() *ArrayPool {
+	return &ArrayPool{}
+}
+
+func (p *ArrayPool) pool(n int, ranked bool) *sync.Pool {
+	if ranked {
+		return &p.pools[1][n]
+	}
+	return &p.pools[0][n]
+}
+
+// A pooled array records its length, since an element keeps its array when
+// shrink lowers the level limit below it. A ranked array keeps it in the
+// unused spans[0], an unranked one gets a header slot which refers to
+// arrayLens[n].
+var arrayLens = func() (lens [MaximumLevel + 1]int) {
+	for i := range lens {
+		lens[i] = i
+	}
+	return lens
+}()
+
+func arrayHeader(array unsafe.Pointer) *unsafe.Pointer {
+	return (*unsafe.Pointer)(unsafe.Add(array, -int(ptrSize)))
+}
+
+// arrayLen returns the length of an array made by get.
+func arrayLen(array unsafe.Pointer, ranked bool) int {
+	if ranked {
+		return (*spanArray)(*arrayHeader(array))[0]
+	}
+	return *(*int)(*arrayHeader(array))
+}
+
+// get returns an array of n nil pointers, and zero spans when ranked.
+func (p *ArrayPool) get(n int, ranked bool) unsafe.Pointer {
+	if array, ok := p.pool(n, ranked).Get().(unsafe.Pointer); ok {
+		return array
+	}
+	if ranked {
+		array := makeRankedArray(n)
+		(*spanArray)(*arrayHeader(array))[0] = n
+		return array
+	}
+	array := unsafe.Add(makePointArray(n+1), ptrSize)
+	*arrayHeader(array) = unsafe.Pointer(&arrayLens[n])
+	return array
+}
+
+// put recycles an array made by get, into the pool of its length.
+func (p *ArrayPool) put(array unsafe.Pointer, ranked bool) {
+	n := arrayLen(array, ranked)
+	ln := (*[MaximumLevel]unsafe.Pointer)(array)
+	for i := 0; i < n; i++ {
+		ln[i] = nil
+	}
+	if ranked {
+		spans := (*spanArray)(*arrayHeader(array))
+		for i := 1; i < n; i++ {
+			spans[i] = 0
+		}
+	}
+	p.pool(n, ranked).Put(array)
+}
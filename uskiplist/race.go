@@ -10,7 +10,13 @@ package uskiplist
 import "unsafe"
 
 func makePointArray(n int) unsafe.Pointer {
-	slice := make([]unsafe.Pointer, n, MaximumLevel)
+	slice := make([]unsafe.Pointer, n, MaximumLevel+1)
+	array := unsafe.SliceData(slice)
+	return unsafe.Pointer(array)
+}
+
+func makeSpanArray(n int) unsafe.Pointer {
+	slice := make([]int, n, MaximumLevel)
 	array := unsafe.SliceData(slice)
 	return unsafe.Pointer(array)
 }
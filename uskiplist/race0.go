@@ -14,3 +14,9 @@ func makePointArray(n int) unsafe.Pointer {
 	array := unsafe.SliceData(slice)
 	return unsafe.Pointer(array)
 }
+
+func makeSpanArray(n int) unsafe.Pointer {
+	slice := make([]int, n)
+	array := unsafe.SliceData(slice)
+	return unsafe.Pointer(array)
+}
@@ -4,7 +4,11 @@
 
 package uskiplist
 
-import "math/rand"
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
 
 // A SplitMix64 provides the SplitMix64 algorithm and implements
 // math/rand.Source64. Can be seeded to any value.
@@ -31,3 +35,19 @@ func (s *splitMix64) Uint64() uint64 {
 func (s *splitMix64) Int63() int64 {
 	return int64(s.Uint64() >> 1)
 }
+
+// NewSource returns a SplitMix64 generator seeded with seed, which is what
+// the skiplists use by default. It is not safe for concurrent use.
+func NewSource(seed int64) rand.Source64 {
+	s := splitMix64(seed)
+	return &s
+}
+
+var seeds atomic.Uint64
+
+// newSeed returns a seed for a new generator, it differs between calls even
+// at the same time.
+func newSeed() int64 {
+	s := splitMix64(uint64(time.Now().UnixNano()) + seeds.Add(1)*0x9e3779b97f4a7c15)
+	return int64(s.Uint64())
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist
+
+import "unsafe"
+
+// In a ranked skiplist, every point array (the root included) has a header
+// slot just before its first pointer, which refers to a span array of the
+// same length. spans[i] is the distance to the next element at level i, or
+// the number of elements behind when there is none. Level 0 always steps
+// one element, so spans[0] is unused and level 1 elements need nothing.
+
+const ptrSize = unsafe.Sizeof(unsafe.Pointer(nil))
+
+type spanArray [MaximumLevel]int
+
+type rankPath [MaximumLevel]int
+
+func makeRankedArray(n int) unsafe.Pointer {
+	array := makePointArray(n + 1)
+	*(*unsafe.Pointer)(array) = makeSpanArray(n)
+	return unsafe.Add(array, ptrSize)
+}
+
+func spansOf[E any](ln *leveln[E]) *spanArray {
+	header := unsafe.Add(unsafe.Pointer(ln), -int(ptrSize))
+	return (*spanArray)(*(*unsafe.Pointer)(header))
+}
+
+// pathSpans returns the span array which path[i] belongs to.
+func pathSpans[E any](path *searchPath[E], i int) *spanArray {
+	return spansOf(pathArray(path, i))
+}
+
+// pathRanks calculates the rank (1-based, 0 means the root) of the owner of
+// each slot in path, path must be filled for all levels.
+func (l *listBase[E, PE]) pathRanks(path *searchPath[E], ranks *rankPath) {
+	r := 0
+	ln := l.root
+	for i := l.maxL - 1; i > 0; i-- {
+		for &ln[i] != path[i] {
+			r += spansOf(ln)[i]
+			ln = PE(ln[i]).lnNext()
+		}
+		ranks[i] = r
+	}
+
+	slot := &ln[0]
+	for slot != path[0] {
+		r++
+		slot = &PE(*slot).l1Next()[0]
+	}
+	ranks[0] = r
+}
+
+// searchIndex fills path for the element at index i of a ranked skiplist.
+//
+//	0 <= i < l.len
+func (l *listBase[E, PE]) searchIndex(i int, path *searchPath[E]) *E {
+	target := i + 1
+
+	r := 0
+	ln := l.root
+	for lev := l.maxL - 1; lev > 0; lev-- {
+		for ln[lev] != nil && r+spansOf(ln)[lev] < target {
+			r += spansOf(ln)[lev]
+			ln = PE(ln[lev]).lnNext()
+		}
+		path[lev] = &ln[lev]
+	}
+
+	slot := &ln[0]
+	for r+1 < target {
+		r++
+		slot = &PE(*slot).l1Next()[0]
+	}
+	path[0] = slot
+
+	return *slot
+}
+
+// indexOf returns the index of e found by path, or -1 when e is nil.
+func (l *listBase[E, PE]) indexOf(e *E, path *searchPath[E]) int {
+	if e == nil {
+		return -1
+	}
+
+	if l.opts.Ranked {
+		ranks := &rankPath{}
+		l.pathRanks(path, ranks)
+		return ranks[0]
+	}
+
+	i, n := -1, 0
+	l.IterateAt(0, func(cur *E) bool {
+		if cur == e {
+			i = n
+			return false
+		}
+		n++
+		return true
+	})
+	return i
+}
+
+// At returns the element at index i (0-based), returns nil when i is out of
+// range. It takes O(log n) in a ranked skiplist, and O(n) otherwise.
+func (l *listBase[E, PE]) At(i int) *E {
+	var e *E
+	l.IterateAt(i, func(cur *E) bool {
+		e = cur
+		return false
+	})
+	return e
+}
+
+// IterateAt will call iterator once for each element from index i (0-based)
+// in ascending order. Locating i takes O(log n) in a ranked skiplist, and
+// O(n) otherwise.
+//
+//	The current element can be deleted in Iterator.
+//	It will stop whenever the iterator returns false.
+func (l *listBase[E, PE]) IterateAt(i int, iterator Iterator[E]) {
+	if i < 0 || i >= l.len {
+		return
+	}
+
+	if l.opts.Ranked {
+		var cur *E
+
+		path := &searchPath[E]{}
+		l.searchIndex(i, path)
+		if *path[0] != *path[1] {
+			cur = *path[0]
+		}
+
+		l.iterate(cur, *path[1], iterator)
+		return
+	}
+
+	var cur, relay *E
+
+	if l.root[0] != l.root[1] {
+		cur = l.root[0]
+	}
+	relay = l.root[1]
+
+	n := 0
+	l.iterate(cur, relay, func(e *E) bool {
+		if n < i {
+			n++
+			return true
+		}
+		return iterator(e)
+	})
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// sampleSize is the least number of elements an approximate Quantile or
+// SampleN is drawn from.
+const sampleSize = 4096
+
+// walk calls fn once for each element linked at level i (0-based) in
+// ascending order, it stops whenever fn returns false.
+func (l *listBase[E, PE]) walk(i int, fn func(*E) bool) {
+	if i == 0 {
+		var cur *E
+		if l.root[0] != l.root[1] {
+			cur = l.root[0]
+		}
+		l.iterate(cur, l.root[1], fn)
+		return
+	}
+
+	for e := l.root[i]; e != nil; e = PE(e).lnNext()[i] {
+		if !fn(e) {
+			return
+		}
+	}
+}
+
+// spread returns the highest level i (0-based) with at least n elements, and
+// the number m of them. Level 0 holds all elements.
+func (l *listBase[E, PE]) spread(n int) (i, m int) {
+	if l.len < n {
+		return 0, l.len
+	}
+
+	p := float64(l.probability())
+	i = int(math.Log(float64(l.len)/float64(n)) / math.Log(1/p))
+	if i > l.maxL-1 {
+		i = l.maxL - 1
+	}
+	for ; i > 0; i-- {
+		m = 0
+		l.walk(i, func(*E) bool {
+			m++
+			return true
+		})
+		if m >= n {
+			return i, m
+		}
+	}
+	return 0, l.len
+}
+
+// levelIndex returns the index of the element, among the m elements of a
+// level, whose expected index in the skiplist is the nearest to r. The levels
+// of elements are independent, so the expected index of the j-th one is
+// (j+1)*(Len+1)/(m+1) - 1.
+func (l *listBase[E, PE]) levelIndex(r, m int) int {
+	j := int(math.Round(float64(r+1)*float64(m+1)/float64(l.len+1))) - 1
+	if j > m-1 {
+		j = m - 1
+	}
+	return j
+}
+
+// Quantile returns the element at about index q*(Len-1), q is clamped to
+// [0, 1]. It returns nil when the skiplist is empty.
+//
+// It is exact and takes O(log n) in a ranked skiplist. Otherwise it walks the
+// highest level with m >= 4096 elements (all elements in a smaller skiplist)
+// and picks the one with the nearest expected index, which takes O(m). Its
+// index error is then about normal, with a standard deviation of about
+// Len*sqrt(q*(1-q)/m), no more than Len/128. The first and the last elements
+// are exact.
+func (l *listBase[E, PE]) Quantile(q float64) *E {
+	switch {
+	case l.len == 0:
+		return nil
+	case !(q > 0):
+		return l.First()
+	case q >= 1:
+		return l.Last()
+	}
+
+	r := int(math.Round(q * float64(l.len-1)))
+	if l.opts.Ranked {
+		return l.At(r)
+	}
+
+	i, m := l.spread(sampleSize)
+	j := l.levelIndex(r, m)
+	if j < 0 {
+		return l.First()
+	}
+
+	var e *E
+	l.walk(i, func(cur *E) bool {
+		if j == 0 {
+			e = cur
+			return false
+		}
+		j--
+		return true
+	})
+	return e
+}
+
+// SampleN returns n elements in ascending order at about the indexes
+// k*Len/n, k in [0, n), or all elements when n >= Len. The first element is
+// always included.
+//
+// It is exact and takes O(n log n) in a ranked skiplist. Otherwise it walks
+// the highest level with m >= max(4096, 4n) elements and picks the ones with
+// the nearest expected indexes, like Quantile, which takes O(m). The index
+// error of the k-th element has a standard deviation of about
+// Len*sqrt(q*(1-q)/m), where q = k/n, no more than Len/128.
+func (l *listBase[E, PE]) SampleN(n int) []*E {
+	if n <= 0 || l.len == 0 {
+		return nil
+	}
+	if n > l.len {
+		n = l.len
+	}
+
+	elems := make([]*E, 0, n)
+	if l.opts.Ranked {
+		for k := 0; k < n; k++ {
+			elems = append(elems, l.At(k*l.len/n))
+		}
+		return elems
+	}
+
+	size := sampleSize
+	if size < 4*n {
+		size = 4 * n
+	}
+	i, m := l.spread(size)
+
+	// last is the level index of the last picked element.
+	last := -1
+	if l.root[i] == l.root[0] {
+		last = 0
+	}
+	elems = append(elems, l.root[0])
+
+	target := func(k int) int {
+		j := l.levelIndex(k*l.len/n, m)
+		if j <= last {
+			j = last + 1
+		}
+		if j > m-(n-k) {
+			j = m - (n - k)
+		}
+		return j
+	}
+
+	j, next := 0, 0
+	if n > 1 {
+		next = target(1)
+	}
+	l.walk(i, func(e *E) bool {
+		if len(elems) == n {
+			return false
+		}
+		if j == next {
+			elems = append(elems, e)
+			last = j
+			if len(elems) < n {
+				next = target(len(elems))
+			}
+		}
+		j++
+		return true
+	})
+	return elems
+}
+
+// RandomSample returns n elements chosen uniformly at random without
+// replacement in ascending order, or all elements when n >= Len. r makes the
+// choices, a generator with a random seed is used when nil.
+//
+// The indexes are chosen by reservoir sampling with geometric skips, in
+// O(n*(1+log(Len/n))) time. Locating the elements takes O(n log n) in a
+// ranked skiplist, and a walk up to the last one otherwise.
+func (l *listBase[E, PE]) RandomSample(n int, r *rand.Rand) []*E {
+	if n <= 0 || l.len == 0 {
+		return nil
+	}
+	if n >= l.len {
+		return l.SampleN(l.len)
+	}
+	if r == nil {
+		r = rand.New(NewSource(newSeed()))
+	}
+
+	idx := reservoir(l.len, n, r)
+	sort.Ints(idx)
+
+	elems := make([]*E, 0, n)
+	if l.opts.Ranked {
+		for _, i := range idx {
+			elems = append(elems, l.At(i))
+		}
+		return elems
+	}
+
+	i := 0
+	l.walk(0, func(e *E) bool {
+		if i == idx[len(elems)] {
+			elems = append(elems, e)
+		}
+		i++
+		return len(elems) < n
+	})
+	return elems
+}
+
+// reservoir chooses n distinct indexes in [0, size) uniformly at random, it
+// skips the indexes which would not enter the reservoir (Algorithm L).
+func reservoir(size, n int, r *rand.Rand) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+
+	w := math.Exp(math.Log(1-r.Float64()) / float64(n))
+	for i := n - 1; ; {
+		skip := math.Floor(math.Log(1-r.Float64()) / math.Log(1-w))
+		if skip >= float64(size-i-1) {
+			break
+		}
+		i += int(skip) + 1
+		idx[r.Intn(n)] = i
+		w *= math.Exp(math.Log(1-r.Float64()) / float64(n))
+	}
+	return idx
+}
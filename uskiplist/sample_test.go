@@ -0,0 +1,124 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/someonegg/gocontainer/uskiplist"
+)
+
+func newSampleList(ranked bool, n int) *testList {
+	l := uskiplist.NewOWith[int, item2](uskiplist.Options{Ranked: ranked})
+	for _, k := range rand.Perm(n) {
+		l.Insert(&item2{k: k})
+	}
+	return l
+}
+
+func TestQuantile(t *testing.T) {
+	for _, ranked := range []bool{false, true} {
+		for _, n := range []int{1, 1000, 200000} {
+			l := newSampleList(ranked, n)
+			// four standard deviations, see Quantile
+			tol := n / 32
+			if ranked || n < 4096 {
+				tol = 0
+			}
+
+			for _, q := range []float64{-1, 0, 0.01, 0.25, 0.5, 0.9, 0.999, 1, 2} {
+				want := int(q*float64(n-1) + 0.5)
+				if q < 0 {
+					want = 0
+				} else if q > 1 {
+					want = n - 1
+				}
+				e := l.Quantile(q)
+				if e == nil || e.k < want-tol || e.k > want+tol {
+					t.Fatalf("ranked=%v n=%d Quantile(%v) = %v, want %d±%d", ranked, n, q, e, want, tol)
+				}
+			}
+		}
+	}
+
+	if e := uskiplist.NewO[int, item2]().Quantile(0.5); e != nil {
+		t.Fatalf("empty Quantile = %v", e)
+	}
+}
+
+func TestSampleN(t *testing.T) {
+	for _, ranked := range []bool{false, true} {
+		for _, n := range []int{1, 1000, 200000} {
+			l := newSampleList(ranked, n)
+			tol := n / 32
+			if ranked || n < 4096 {
+				tol = 0
+			}
+
+			for _, size := range []int{1, 2, 10, 999, 5000} {
+				got := l.SampleN(size)
+				want := size
+				if want > n {
+					want = n
+				}
+				if len(got) != want || got[0].k != 0 {
+					t.Fatalf("ranked=%v n=%d SampleN(%d) = %d elements", ranked, n, size, len(got))
+				}
+				for k, e := range got {
+					if k > 0 && e.k <= got[k-1].k {
+						t.Fatalf("ranked=%v n=%d SampleN(%d) not ascending at %d", ranked, n, size, k)
+					}
+					if r := k * n / want; e.k < r-tol || e.k > r+tol {
+						t.Fatalf("ranked=%v n=%d SampleN(%d)[%d] = %d, want %d±%d", ranked, n, size, k, e.k, r, tol)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRandomSample(t *testing.T) {
+	for _, ranked := range []bool{false, true} {
+		const n = 20000
+		l := newSampleList(ranked, n)
+
+		a := l.RandomSample(1000, rand.New(uskiplist.NewSource(1)))
+		b := l.RandomSample(1000, rand.New(uskiplist.NewSource(1)))
+		if len(a) != 1000 || len(b) != 1000 {
+			t.Fatalf("ranked=%v RandomSample = %d elements", ranked, len(a))
+		}
+		sum := 0
+		for i, e := range a {
+			if e != b[i] {
+				t.Fatalf("ranked=%v same seed, different samples", ranked)
+			}
+			if i > 0 && e.k <= a[i-1].k {
+				t.Fatalf("ranked=%v RandomSample not ascending at %d", ranked, i)
+			}
+			sum += e.k
+		}
+		// the mean of a uniform sample, about five standard deviations
+		if mean := sum / len(a); mean < n/2-1000 || mean > n/2+1000 {
+			t.Fatalf("ranked=%v RandomSample mean = %d", ranked, mean)
+		}
+
+		hits := make([]int, 10)
+		for i := 0; i < 100; i++ {
+			for _, e := range l.RandomSample(100, nil) {
+				hits[e.k*10/n]++
+			}
+		}
+		sort.Ints(hits)
+		if hits[0] < 850 || hits[9] > 1150 {
+			t.Fatalf("ranked=%v RandomSample deciles = %v", ranked, hits)
+		}
+
+		if got := l.RandomSample(n+1, nil); len(got) != n {
+			t.Fatalf("ranked=%v RandomSample(n+1) = %d elements", ranked, len(got))
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 someonegg. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uskiplist
+
+import "unsafe"
+
+// Stats describes the shape and the memory usage of a skiplist.
+type Stats struct {
+	// Len is the number of elements.
+	Len int
+	// MaxLevel is the current level limit, see InitialLevel.
+	MaxLevel int
+	// Levels[i] is the number of elements linked at level i (0-based), so
+	// Levels[0] is Len. It has MaxLevel items.
+	Levels []int
+
+	// ArrayBytes is the size of the point arrays, and the span arrays of a
+	// ranked skiplist, the root included. The root is counted with the levels
+	// kept after a shrink, the elements with their linked levels. Allocator
+	// overhead is not counted.
+	ArrayBytes int
+	// PointerWords is the average number of pointer words per element, the
+	// embedded one included, without the root and span arrays.
+	PointerWords float64
+
+	// SearchDepth estimates the average number of elements visited by a
+	// search, assuming the elements are evenly spread over each level.
+	SearchDepth float64
+}
+
+// Stats walks the skiplist and returns its statistics, it takes O(n) time.
+func (l *listBase[E, PE]) Stats() Stats {
+	s := Stats{
+		Len:      l.len,
+		MaxLevel: l.maxL,
+		Levels:   make([]int, l.maxL),
+	}
+	s.Levels[0] = l.len
+	for i := 1; i < l.maxL; i++ {
+		for e := l.root[i]; e != nil; e = PE(e).lnNext()[i] {
+			s.Levels[i]++
+		}
+	}
+
+	// An element of level n > 1 has an array of n pointers.
+	arrays, pointers := 0, 0
+	for i := 1; i < l.maxL; i++ {
+		pointers += s.Levels[i]
+	}
+	if l.maxL > 1 {
+		arrays = s.Levels[1]
+		pointers += arrays
+	}
+	if l.len > 0 {
+		s.PointerWords = float64(l.len+pointers) / float64(l.len)
+	}
+
+	arrays++
+	pointers += l.rootL
+	s.ArrayBytes = pointers * int(ptrSize)
+	if l.opts.Ranked {
+		// a header slot and a span for each pointer
+		s.ArrayBytes += arrays*int(ptrSize) + pointers*int(unsafe.Sizeof(0))
+	}
+
+	// At each level, a search walks half of the gap between the elements of
+	// the level above.
+	above := 1
+	for i := l.maxL - 1; i >= 0; i-- {
+		if s.Levels[i] == 0 {
+			continue
+		}
+		s.SearchDepth += (float64(s.Levels[i])/float64(above) + 1) / 2
+		above = s.Levels[i]
+	}
+	return s
+}